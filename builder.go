@@ -0,0 +1,60 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalcluster
+
+import "strings"
+
+// Builder incrementally constructs a logical cluster name from segments with
+// less allocations than repeated calls to Name.Join. The zero value is an empty
+// builder ready to use.
+type Builder struct {
+	b    strings.Builder
+	used bool
+}
+
+// Append appends a name component.
+func (b *Builder) Append(segment string) *Builder {
+	if b.used {
+		b.b.WriteString(separator)
+	}
+	b.b.WriteString(segment)
+	b.used = true
+	return b
+}
+
+// AppendName appends all segments of n. The empty name appends nothing.
+func (b *Builder) AppendName(n Name) *Builder {
+	if n.value == "" {
+		return b
+	}
+	return b.Append(n.value)
+}
+
+// Build returns the logical cluster name built so far.
+func (b *Builder) Build() Name {
+	return Name{b.b.String()}
+}
+
+// BuildValid is like Build, but returns an *InvalidNameError if the resulting
+// name is not valid.
+func (b *Builder) BuildValid() (Name, error) {
+	n := b.Build()
+	if err := n.Validate(); err != nil {
+		return Name{}, err
+	}
+	return n, nil
+}
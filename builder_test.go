@@ -0,0 +1,94 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalcluster
+
+import "testing"
+
+func TestBuilder(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(b *Builder) *Builder
+		want  Name
+	}{
+		{"empty", func(b *Builder) *Builder { return b }, New("")},
+		{"single", func(b *Builder) *Builder { return b.Append("root") }, New("root").Join()},
+		{"segments", func(b *Builder) *Builder { return b.Append("root").Append("a").Append("b") }, New("root").Join("a").Join("b")},
+		{"name", func(b *Builder) *Builder { return b.AppendName(New("root:a")).Append("b") }, New("root:a").Join("b")},
+		{"empty name", func(b *Builder) *Builder { return b.AppendName(New("")).Append("root").AppendName(New("")) }, New("").Join("root")},
+		{"names", func(b *Builder) *Builder { return b.AppendName(New("root:a")).AppendName(New("b:c")) }, New("root:a").Join("b:c")},
+		{"wildcard", func(b *Builder) *Builder { return b.AppendName(Wildcard) }, Wildcard},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b Builder
+			if got := tt.build(&b).Build(); got != tt.want {
+				t.Errorf("Build() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuilder_BuildValid(t *testing.T) {
+	var b Builder
+	got, err := b.Append("root").Append("a").BuildValid()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != New("root:a") {
+		t.Errorf("BuildValid() = %q, want %q", got, "root:a")
+	}
+
+	_, err = b.Append("Invalid").BuildValid()
+	if expected := `invalid logical cluster name "root:a:Invalid": illegal character 'I' at index 7`; err == nil || err.Error() != expected {
+		t.Errorf("incorrect error, expected %s, got %v", expected, err)
+	}
+
+	var empty Builder
+	if _, err := empty.BuildValid(); err == nil {
+		t.Errorf("expected error building empty name")
+	}
+}
+
+var benchmarkSegments = []string{"root", "accounting", "us-west", "team-a", "invoices", "2022", "q1", "drafts"}
+
+func BenchmarkJoin(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var n Name
+		for _, s := range benchmarkSegments {
+			n = n.Join(s)
+		}
+	}
+}
+
+func BenchmarkJoinVariadic(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		New("").Join(benchmarkSegments...)
+	}
+}
+
+func BenchmarkBuilder(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var builder Builder
+		for _, s := range benchmarkSegments {
+			builder.Append(s)
+		}
+		builder.Build()
+	}
+}
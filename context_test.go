@@ -0,0 +1,42 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalcluster
+
+import (
+	"context"
+	"testing"
+)
+
+func TestClusterFromContext(t *testing.T) {
+	ctx := context.Background()
+	if got, ok := ClusterFromContext(ctx); ok || got != New("") {
+		t.Errorf("ClusterFromContext() = (%q, %v), want (%q, false)", got, ok, "")
+	}
+
+	ctx = WithCluster(ctx, New("root:a"))
+	if got, ok := ClusterFromContext(ctx); !ok || got != New("root:a") {
+		t.Errorf("ClusterFromContext() = (%q, %v), want (%q, true)", got, ok, "root:a")
+	}
+
+	overwritten := WithCluster(ctx, New("root:b"))
+	if got, ok := ClusterFromContext(overwritten); !ok || got != New("root:b") {
+		t.Errorf("ClusterFromContext() = (%q, %v), want (%q, true)", got, ok, "root:b")
+	}
+	if got, ok := ClusterFromContext(ctx); !ok || got != New("root:a") {
+		t.Errorf("ClusterFromContext() of parent context = (%q, %v), want (%q, true)", got, ok, "root:a")
+	}
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalcluster
+
+import (
+	"encoding/base32"
+	"fmt"
+)
+
+// segmentEncoding is the lower-case base32 encoding with the extended hex
+// alphabet 0-9a-v and without padding.
+var segmentEncoding = base32.NewEncoding("0123456789abcdefghijklmnopqrstuv").WithPadding(base32.NoPadding)
+
+// EscapeSegment encodes an arbitrary string, e.g. an external identifier with
+// upper-case letters, underscores or colons, as a single name component using
+// lower-case base32 with the extended hex alphabet 0-9a-v and without padding.
+// The result only consists of lower-case letters and digits. Note that it is
+// longer than the input, i.e. only inputs of up to 39 bytes result in a valid
+// segment of at most 63 characters, and that the empty string is escaped to an
+// empty, hence invalid, segment.
+func EscapeSegment(raw string) string {
+	return segmentEncoding.EncodeToString([]byte(raw))
+}
+
+// UnescapeSegment decodes a name component produced by EscapeSegment.
+func UnescapeSegment(segment string) (string, error) {
+	raw, err := segmentEncoding.DecodeString(segment)
+	if err != nil {
+		return "", fmt.Errorf("invalid escaped logical cluster name component %q: %w", segment, err)
+	}
+	if segmentEncoding.EncodeToString(raw) != segment {
+		// trailing bits must be zero for the encoding to be reversible
+		return "", fmt.Errorf("invalid escaped logical cluster name component %q: not canonical", segment)
+	}
+	return string(raw), nil
+}
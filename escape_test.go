@@ -0,0 +1,67 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalcluster
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEscapeSegment(t *testing.T) {
+	for _, raw := range []string{
+		"a",
+		"Hello_World",
+		"UPPERCASE",
+		"root:a",
+		":",
+		"ünïcødé",
+		"日本語",
+		"with spaces and\nnewlines",
+		strings.Repeat("x", 39),
+	} {
+		t.Run(raw, func(t *testing.T) {
+			escaped := EscapeSegment(raw)
+			if n := New(escaped); !n.IsValid() || n.Depth() != 1 {
+				t.Errorf("EscapeSegment(%q) = %q, which is not a valid single segment", raw, escaped)
+			}
+			got, err := UnescapeSegment(escaped)
+			if err != nil {
+				t.Fatalf("UnescapeSegment(%q) failed: %v", escaped, err)
+			}
+			if got != raw {
+				t.Errorf("UnescapeSegment(EscapeSegment(%q)) = %q", raw, got)
+			}
+		})
+	}
+
+	if got := EscapeSegment(""); got != "" {
+		t.Errorf("EscapeSegment(%q) = %q, want empty", "", got)
+	}
+	if got := EscapeSegment("a"); got != "c4" {
+		t.Errorf("EscapeSegment(%q) = %q, want %q", "a", got, "c4")
+	}
+}
+
+func TestUnescapeSegment_Invalid(t *testing.T) {
+	for _, segment := range []string{"C4", "zz", "c4:c4", "c4-", "c4======", "c5", "0"} {
+		t.Run(segment, func(t *testing.T) {
+			if got, err := UnescapeSegment(segment); err == nil {
+				t.Errorf("expected error unescaping %q, got %q", segment, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalcluster
+
+// Set implements flag.Value. It accepts valid logical cluster names only, and
+// returns an *InvalidNameError otherwise.
+func (n *Name) Set(value string) error {
+	if err := New(value).Validate(); err != nil {
+		return err
+	}
+	n.value = value
+	return nil
+}
+
+// Type implements the Value interface of github.com/spf13/pflag, such that a
+// Name can be registered as a pflag without this package depending on it.
+func (n *Name) Type() string {
+	return "name"
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalcluster
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"testing"
+)
+
+var _ flag.Value = &Name{}
+
+// pflagValue mirrors the Value interface of github.com/spf13/pflag.
+type pflagValue interface {
+	String() string
+	Set(string) error
+	Type() string
+}
+
+var _ pflagValue = &Name{}
+
+func TestName_Set(t *testing.T) {
+	tests := []struct {
+		args    []string
+		want    Name
+		wantErr bool
+	}{
+		{nil, New("root"), false},
+		{[]string{"--cluster=root:a"}, New("root:a"), false},
+		{[]string{"--cluster", "*"}, Wildcard, false},
+		{[]string{"--cluster="}, New("root"), true},
+		{[]string{"--cluster=root:"}, New("root"), true},
+		{[]string{"--cluster=Root"}, New("root"), true},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.args), func(t *testing.T) {
+			cluster := New("root")
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			fs.Var(&cluster, "cluster", "the logical cluster")
+
+			err := fs.Parse(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cluster != tt.want {
+				t.Errorf("incorrect cluster, expected %q, got %q", tt.want, cluster)
+			}
+			if got := fs.Lookup("cluster").Value.String(); got != tt.want.String() {
+				t.Errorf("incorrect flag value, expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestName_Type(t *testing.T) {
+	var v pflagValue = &Name{}
+	if got := v.Type(); got != "name" {
+		t.Errorf("Type() = %q, want %q", got, "name")
+	}
+	if err := v.Set("root:a"); err != nil {
+		t.Fatal(err)
+	}
+	if got := v.String(); got != "root:a" {
+		t.Errorf("String() = %q, want %q", got, "root:a")
+	}
+	err := v.Set("root:A")
+	if expected := `invalid logical cluster name "root:A": illegal character 'A' at index 5`; err == nil || err.Error() != expected {
+		t.Errorf("incorrect error, expected %s, got %v", expected, err)
+	}
+	var invalid *InvalidNameError
+	if !errors.As(err, &invalid) {
+		t.Errorf("expected *InvalidNameError, got %T", err)
+	}
+}
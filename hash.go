@@ -0,0 +1,37 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalcluster
+
+import "hash/fnv"
+
+// Hash returns a hash of the logical cluster name, computed as 64-bit FNV-1a
+// over its string representation. The algorithm is stable across releases, such
+// that hashes and derived shard assignments can be persisted.
+func (n Name) Hash() uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(n.value))
+	return h.Sum64()
+}
+
+// ShardIndex returns the shard in [0, shards) the logical cluster name is
+// assigned to based on Hash. It panics if shards <= 0.
+func (n Name) ShardIndex(shards int) int {
+	if shards <= 0 {
+		panic("logicalcluster: invalid number of shards for ShardIndex")
+	}
+	return int(n.Hash() % uint64(shards))
+}
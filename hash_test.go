@@ -0,0 +1,56 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalcluster
+
+import "testing"
+
+func TestName_Hash(t *testing.T) {
+	// these values must never change, as shard assignments are persisted
+	tests := []struct {
+		name  Name
+		hash  uint64
+		shard int
+	}{
+		{New(""), 0xcbf29ce484222325, 2},
+		{Wildcard, 0xaf63a74c8601927d, 0},
+		{New("root"), 0xa354fd1ff0c467c5, 3},
+		{New("root:a"), 0xf8bc372487a98cc4, 3},
+		{New("system:admin"), 0xee81e638252d34f1, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String(), func(t *testing.T) {
+			if got := tt.name.Hash(); got != tt.hash {
+				t.Errorf("%q.Hash() = %#x, want %#x", tt.name, got, tt.hash)
+			}
+			if got := tt.name.ShardIndex(7); got != tt.shard {
+				t.Errorf("%q.ShardIndex(7) = %d, want %d", tt.name, got, tt.shard)
+			}
+			if got := tt.name.ShardIndex(1); got != 0 {
+				t.Errorf("%q.ShardIndex(1) = %d, want 0", tt.name, got)
+			}
+		})
+	}
+}
+
+func TestName_ShardIndexPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected ShardIndex(0) to panic")
+		}
+	}()
+	New("root").ShardIndex(0)
+}
@@ -0,0 +1,47 @@
+//go:build go1.23
+
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalcluster
+
+import (
+	"iter"
+	"strings"
+)
+
+// All returns an iterator over the segments of the logical cluster name, from
+// the root to the last component. The empty name has no segments.
+func (n Name) All() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		if n.value == "" {
+			return
+		}
+		for rest, more := n.value, true; more; {
+			var segment string
+			segment, rest, more = strings.Cut(rest, separator)
+			if !yield(segment) {
+				return
+			}
+		}
+	}
+}
+
+// AllUp returns an iterator over the logical cluster name itself and its
+// ancestors up to the root, in the same order as WalkUp.
+func (n Name) AllUp() iter.Seq[Name] {
+	return n.WalkUp
+}
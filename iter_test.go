@@ -0,0 +1,83 @@
+//go:build go1.23
+
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalcluster
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestName_All(t *testing.T) {
+	tests := []struct {
+		name Name
+		want []string
+	}{
+		{New(""), nil},
+		{Wildcard, []string{"*"}},
+		{New("root:a:b"), []string{"root", "a", "b"}},
+		{New("foo::bar"), []string{"foo", "", "bar"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String(), func(t *testing.T) {
+			var got []string
+			for segment := range tt.name.All() {
+				got = append(got, segment)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q.All() yielded %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+
+	var got []string
+	for segment := range New("root:a:b").All() {
+		got = append(got, segment)
+		if segment == "a" {
+			break
+		}
+	}
+	if want := []string{"root", "a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("All() with break yielded %q, want %q", got, want)
+	}
+}
+
+func TestName_AllUp(t *testing.T) {
+	var got []Name
+	for n := range New("root:a:b").AllUp() {
+		got = append(got, n)
+	}
+	if want := []Name{New("root:a:b"), New("root:a"), New("root")}; !reflect.DeepEqual(got, want) {
+		t.Errorf("AllUp() yielded %v, want %v", got, want)
+	}
+
+	got = nil
+	for n := range New("root:a:b").AllUp() {
+		if n == New("root:a") {
+			break
+		}
+		got = append(got, n)
+	}
+	if want := []Name{New("root:a:b")}; !reflect.DeepEqual(got, want) {
+		t.Errorf("AllUp() with break yielded %v, want %v", got, want)
+	}
+
+	for n := range New("").AllUp() {
+		t.Errorf("unexpected %q yielded for the empty name", n)
+	}
+}
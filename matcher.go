@@ -0,0 +1,143 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalcluster
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Matcher matches logical cluster names against a pattern compiled once with
+// CompileMatcher, with the semantics of Name.Matches.
+type Matcher struct {
+	pattern  string
+	segments []string
+	// anyDepth is true if the pattern contains "**".
+	anyDepth bool
+}
+
+// CompileMatcher compiles a pattern for Name.Matches into a Matcher. Every
+// segment of the pattern must be a valid name component, "*" or "**".
+func CompileMatcher(pattern string) (*Matcher, error) {
+	if pattern == "" {
+		return nil, fmt.Errorf("invalid logical cluster pattern %q: must not be empty", pattern)
+	}
+	m := &Matcher{pattern: pattern, segments: strings.Split(pattern, separator)}
+	for _, segment := range m.segments {
+		switch {
+		case segment == "**":
+			m.anyDepth = true
+		case segment == "*":
+		case !isValidName(segment):
+			return nil, fmt.Errorf("invalid logical cluster pattern %q: segment %q is neither a valid name component nor * or **", pattern, segment)
+		}
+	}
+	return m, nil
+}
+
+// Match returns true if n matches the pattern.
+func (m *Matcher) Match(n Name) bool {
+	if m.anyDepth {
+		return matchSegments(n.Segments(), m.segments)
+	}
+
+	// without "**", walk the name without allocating
+	if n.value == "" {
+		return false
+	}
+	rest, more := n.value, true
+	for _, pattern := range m.segments {
+		if !more {
+			return false
+		}
+		var segment string
+		segment, rest, more = strings.Cut(rest, separator)
+		if pattern != segment && (pattern != "*" || segment == "") {
+			return false
+		}
+	}
+	return !more
+}
+
+// String returns the pattern the matcher was compiled from.
+func (m *Matcher) String() string {
+	return m.pattern
+}
+
+// MatcherSet matches logical cluster names against many patterns, e.g. an allow
+// list. Patterns are grouped by their first segment, such that only patterns
+// with the same literal first segment as a name, or with "*" or "**" as first
+// segment, are evaluated. The zero value is an empty set ready to use.
+type MatcherSet struct {
+	matchers []*Matcher
+	// byRoot maps a literal first segment to the indexes of its matchers.
+	byRoot map[string][]int
+	// wildcardRoot holds the indexes of the matchers starting with "*" or "**".
+	wildcardRoot []int
+}
+
+// NewMatcherSet compiles the patterns into a MatcherSet.
+func NewMatcherSet(patterns ...string) (*MatcherSet, error) {
+	s := &MatcherSet{}
+	for _, pattern := range patterns {
+		m, err := CompileMatcher(pattern)
+		if err != nil {
+			return nil, err
+		}
+		s.Add(m)
+	}
+	return s, nil
+}
+
+// Add adds a matcher to the set.
+func (s *MatcherSet) Add(m *Matcher) {
+	i := len(s.matchers)
+	s.matchers = append(s.matchers, m)
+	if root := m.segments[0]; root == "*" || root == "**" {
+		s.wildcardRoot = append(s.wildcardRoot, i)
+		return
+	}
+	if s.byRoot == nil {
+		s.byRoot = map[string][]int{}
+	}
+	s.byRoot[m.segments[0]] = append(s.byRoot[m.segments[0]], i)
+}
+
+// Match returns the first added matcher matching n, or false if none does.
+func (s *MatcherSet) Match(n Name) (*Matcher, bool) {
+	root, _, _ := strings.Cut(n.value, separator)
+	literal, wildcard := s.byRoot[root], s.wildcardRoot
+
+	// evaluate both candidate lists in the order the matchers were added
+	for len(literal) > 0 || len(wildcard) > 0 {
+		var i int
+		if len(wildcard) == 0 || (len(literal) > 0 && literal[0] < wildcard[0]) {
+			i, literal = literal[0], literal[1:]
+		} else {
+			i, wildcard = wildcard[0], wildcard[1:]
+		}
+		if s.matchers[i].Match(n) {
+			return s.matchers[i], true
+		}
+	}
+	return nil, false
+}
+
+// Len returns the number of matchers in the set.
+func (s *MatcherSet) Len() int {
+	return len(s.matchers)
+}
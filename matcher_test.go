@@ -0,0 +1,151 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalcluster
+
+import "testing"
+
+func TestCompileMatcher(t *testing.T) {
+	for _, pattern := range []string{"root", "*", "**", "root:*:invoices", "root:**", "**:invoices", "root:**:a-b:*"} {
+		if _, err := CompileMatcher(pattern); err != nil {
+			t.Errorf("unexpected error compiling %q: %v", pattern, err)
+		}
+	}
+
+	tests := []struct {
+		pattern string
+		wantErr string
+	}{
+		{"", `invalid logical cluster pattern "": must not be empty`},
+		{"root:", `invalid logical cluster pattern "root:": segment "" is neither a valid name component nor * or **`},
+		{"root::a", `invalid logical cluster pattern "root::a": segment "" is neither a valid name component nor * or **`},
+		{"root:A", `invalid logical cluster pattern "root:A": segment "A" is neither a valid name component nor * or **`},
+		{"root:***", `invalid logical cluster pattern "root:***": segment "***" is neither a valid name component nor * or **`},
+		{"root:a*", `invalid logical cluster pattern "root:a*": segment "a*" is neither a valid name component nor * or **`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			_, err := CompileMatcher(tt.pattern)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("incorrect error, expected %s, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestMatcher_Match(t *testing.T) {
+	patterns := []string{"root", "*", "**", "root:*:invoices", "root:**", "**:invoices", "root:**:invoices", "*:**:c", "root:a"}
+	names := []Name{
+		New(""), New("root"), New("other"), New("root:a"), New("root:ab"), New("root:a:invoices"),
+		New("root:a:b:invoices"), New("root:invoices"), New("root::invoices"), New("root:a:b:c"), New("c"), Wildcard,
+	}
+	for _, pattern := range patterns {
+		m, err := CompileMatcher(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m.String() != pattern {
+			t.Errorf("String() = %q, want %q", m.String(), pattern)
+		}
+		for _, n := range names {
+			if got, want := m.Match(n), n.Matches(New(pattern)); got != want {
+				t.Errorf("CompileMatcher(%q).Match(%q) = %v, but Matches() = %v", pattern, n, got, want)
+			}
+		}
+	}
+}
+
+func BenchmarkMatch(b *testing.B) {
+	names := []Name{New("root:a:invoices"), New("root:accounting:us-west:invoices"), New("root:a:payments"), New("other")}
+
+	for _, pattern := range []string{"root:*:invoices", "root:**:invoices"} {
+		b.Run(pattern+"/compiled", func(b *testing.B) {
+			m, err := CompileMatcher(pattern)
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for _, n := range names {
+					m.Match(n)
+				}
+			}
+		})
+		b.Run(pattern+"/adhoc", func(b *testing.B) {
+			p := New(pattern)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for _, n := range names {
+					n.Matches(p)
+				}
+			}
+		})
+	}
+}
+
+func TestMatcherSet(t *testing.T) {
+	s, err := NewMatcherSet(
+		"root:a:invoices",
+		"root:*:invoices",
+		"**:payments",
+		"root:**",
+		"other:*",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Len() != 5 {
+		t.Errorf("Len() = %d, want 5", s.Len())
+	}
+
+	tests := []struct {
+		name Name
+		want string
+	}{
+		{New("root:a:invoices"), "root:a:invoices"},
+		{New("root:b:invoices"), "root:*:invoices"},
+		{New("root:b:payments"), "**:payments"},
+		{New("other:payments"), "**:payments"},
+		{New("root:b:other"), "root:**"},
+		{New("root"), "root:**"},
+		{New("other:x"), "other:*"},
+		{New("other"), ""},
+		{New("other:x:y"), ""},
+		{New("third:x"), ""},
+		{New(""), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String(), func(t *testing.T) {
+			m, ok := s.Match(tt.name)
+			if ok != (tt.want != "") {
+				t.Fatalf("Match(%q) = (%v, %v), want %q", tt.name, m, ok, tt.want)
+			}
+			if ok && m.String() != tt.want {
+				t.Errorf("Match(%q) matched %q, want %q", tt.name, m, tt.want)
+			}
+		})
+	}
+
+	if _, err := NewMatcherSet("root", "root:"); err == nil {
+		t.Errorf("expected error for invalid pattern")
+	}
+
+	var empty MatcherSet
+	if m, ok := empty.Match(New("root")); ok {
+		t.Errorf("unexpected match %q in empty set", m)
+	}
+}
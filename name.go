@@ -18,8 +18,10 @@ package logicalcluster
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/url"
 	"path"
-	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -44,7 +46,9 @@ type Name struct {
 const separator = ":"
 
 var (
-	// Wildcard is the name indicating cross-workspace requests.
+	// Wildcard is the name indicating cross-workspace requests. It must not be
+	// modified. Use IsWildcard to check for it, which does not depend on this
+	// variable.
 	Wildcard = New("*")
 
 	// None is the name indicating a cluster-unaware context.
@@ -66,6 +70,19 @@ func NewValidated(value string) (Name, bool) {
 	return n, n.IsValid()
 }
 
+// Clone returns a copy of the name. As names are immutable, this is the name
+// itself, and methods returning slices like Segments always return fresh ones
+// that can be modified freely.
+func (n Name) Clone() Name {
+	return n
+}
+
+// IsWildcard returns true if the name is the wildcard "*", regardless of
+// whether it is the Wildcard variable or constructed independently.
+func (n Name) IsWildcard() bool {
+	return n.value == "*"
+}
+
 // Empty returns true if the logical cluster value is unset.
 func (n Name) Empty() bool {
 	return n.value == ""
@@ -76,11 +93,71 @@ func (n Name) Path() string {
 	return path.Join("/clusters", n.value)
 }
 
+// RequestPath returns the request path prefix /clusters/<name> of the logical
+// cluster. It is equivalent to Path and matches the inverse of ParseRequestPath.
+func (n Name) RequestPath() string {
+	return n.Path()
+}
+
+// EscapedRequestPath is like Path, but percent-escapes the logical cluster name
+// such that it can be safely embedded as a single URL path segment, e.g. when
+// composing URLs from untrusted names. Characters are escaped as by
+// url.PathEscape, except for "*", which is allowed in path segments and hence
+// kept for Wildcard. Valid names only consist of characters that need no
+// escaping, i.e. for them the result equals Path.
+func (n Name) EscapedRequestPath() string {
+	return "/clusters/" + strings.ReplaceAll(url.PathEscape(n.value), "%2A", "*")
+}
+
+// ParseRequestPath is the inverse of Path. It extracts the logical cluster name
+// from a request path of the form /clusters/<lcluster>[/...], ignoring anything
+// following the logical cluster name. It returns false if the path does not
+// start with /clusters/ or the logical cluster name is invalid.
+func ParseRequestPath(urlPath string) (Name, bool) {
+	const prefix = "/clusters/"
+	if !strings.HasPrefix(urlPath, prefix) {
+		return Name{}, false
+	}
+	name, _, _ := strings.Cut(urlPath[len(prefix):], "/")
+	n := Name{name}
+	if !n.IsValid() {
+		return Name{}, false
+	}
+	return n, true
+}
+
+// ParseAny returns the logical cluster name referenced by s, which is either the
+// name itself or a path of the form /clusters/<lcluster>, optionally with a
+// trailing slash. It returns false if the name is invalid.
+func ParseAny(s string) (Name, bool) {
+	n := Name{strings.TrimSuffix(strings.TrimPrefix(s, "/clusters/"), "/")}
+	if !n.IsValid() {
+		return Name{}, false
+	}
+	return n, true
+}
+
 // String returns the string representation of the logical cluster name.
 func (n Name) String() string {
 	return n.value
 }
 
+// GoString implements fmt.GoStringer, formatting the name for %#v as the Go
+// expression constructing it, e.g. logicalcluster.New("root:a").
+func (n Name) GoString() string {
+	return fmt.Sprintf("logicalcluster.New(%q)", n.value)
+}
+
+// LogKey is the key under which logical cluster names are logged.
+const LogKey = "cluster"
+
+// LogField returns LogKey and the string representation of the name, to be
+// adapted to structured loggers without this package depending on them, e.g.
+// zap.String(n.LogField()).
+func (n Name) LogField() (key, value string) {
+	return LogKey, n.value
+}
+
 // Object is a local interface representation of the Kubernetes metav1.Object, to avoid dependencies on
 // k8s.io/apimachinery.
 type Object interface {
@@ -90,9 +167,72 @@ type Object interface {
 // AnnotationKey is the name of the annotation key used to denote an object's logical cluster.
 const AnnotationKey = "kcp.dev/cluster"
 
-// From returns the logical cluster name for obj.
+// From returns the logical cluster name for obj. The annotation value, including
+// all of its segments, is returned as is without validation.
 func From(obj Object) Name {
-	return Name{obj.GetAnnotations()[AnnotationKey]}
+	return FromWithKey(obj, AnnotationKey)
+}
+
+// FromWithKey returns the logical cluster name for obj stored in the annotation
+// with the given key, e.g. to read from an alternative key during a migration.
+func FromWithKey(obj Object, key string) Name {
+	return Name{obj.GetAnnotations()[key]}
+}
+
+// Annotation returns the key and value of the annotation denoting the logical
+// cluster name, i.e. AnnotationKey and the string representation of the name,
+// e.g. to write it with annotations[key] = value.
+func (n Name) Annotation() (key, value string) {
+	return AnnotationKey, n.value
+}
+
+// FromLabels returns the logical cluster name for obj stored in the label with
+// the given key.
+func FromLabels(obj interface{ GetLabels() map[string]string }, key string) Name {
+	return Name{obj.GetLabels()[key]}
+}
+
+// LabeledObject is an Object that also has labels.
+type LabeledObject interface {
+	Object
+	GetLabels() map[string]string
+}
+
+// FromObject returns the logical cluster name for obj from the AnnotationKey
+// annotation, falling back to the label with the given key if the annotation
+// is not set.
+func FromObject(obj LabeledObject, labelKey string) Name {
+	if n := From(obj); !n.Empty() {
+		return n
+	}
+	return FromLabels(obj, labelKey)
+}
+
+// MutableObject is an Object whose annotations can be set.
+type MutableObject interface {
+	Object
+	SetAnnotations(map[string]string)
+}
+
+// SetOn sets the logical cluster name annotation on obj, initializing the
+// annotations if necessary.
+func SetOn(obj MutableObject, name Name) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[AnnotationKey] = name.value
+	obj.SetAnnotations(annotations)
+}
+
+// ClearOn removes the logical cluster name annotation from obj.
+func ClearOn(obj MutableObject) {
+	annotations := obj.GetAnnotations()
+	if _, found := annotations[AnnotationKey]; !found {
+		return
+	}
+	delete(annotations, AnnotationKey)
+	obj.SetAnnotations(annotations)
 }
 
 // Parent returns the parent logical cluster name of the given logical cluster name.
@@ -101,6 +241,110 @@ func (n Name) Parent() (Name, bool) {
 	return parent, parent.value != ""
 }
 
+// ParentN returns the ancestor the given number of levels above the logical
+// cluster name, e.g. "root:a" for "root:a:b:c" and 2 levels. It returns false if
+// that would go above the root. For levels of zero or less, the name itself is
+// returned.
+func (n Name) ParentN(levels int) (Name, bool) {
+	cur := n
+	for i := 0; i < levels; i++ {
+		var ok bool
+		if cur, ok = cur.Parent(); !ok {
+			return Name{}, false
+		}
+	}
+	return cur, true
+}
+
+// Ancestors returns all proper ancestors of the logical cluster name, starting
+// with the immediate parent and ending with the root. The empty name and names
+// with a single segment, including Wildcard, have no ancestors.
+func (n Name) Ancestors() []Name {
+	ancestors := make([]Name, 0, n.Depth())
+	for parent, ok := n.Parent(); ok; parent, ok = parent.Parent() {
+		ancestors = append(ancestors, parent)
+	}
+	return ancestors
+}
+
+// Prefixes returns the root and all names below it down to the logical cluster
+// name itself, in this order, e.g. [root, root:a, root:a:b] for "root:a:b".
+// Unlike Ancestors, it includes the name itself. The empty name has no
+// prefixes.
+func (n Name) Prefixes() []Name {
+	prefixes := make([]Name, 0, n.Depth())
+	for i := 0; i < len(n.value); i++ {
+		if n.value[i] == separator[0] {
+			prefixes = append(prefixes, Name{n.value[:i]})
+		}
+	}
+	if n.value != "" {
+		prefixes = append(prefixes, n)
+	}
+	return prefixes
+}
+
+// Breadcrumb is a segment of a logical cluster name together with the name up
+// to and including that segment.
+type Breadcrumb struct {
+	Segment string
+	Name    Name
+}
+
+// Breadcrumbs returns the segments of the logical cluster name paired with
+// the names they end, from the root down to the name itself, e.g.
+// [(root, root), (a, root:a), (b, root:a:b)] for "root:a:b". The empty name
+// has no breadcrumbs.
+func (n Name) Breadcrumbs() []Breadcrumb {
+	prefixes := n.Prefixes()
+	crumbs := make([]Breadcrumb, 0, len(prefixes))
+	for _, p := range prefixes {
+		crumbs = append(crumbs, Breadcrumb{Segment: p.Base(), Name: p})
+	}
+	return crumbs
+}
+
+// WalkUp calls fn for the logical cluster name itself and then for each of its
+// ancestors up to the root, in the same order as Ancestors, but without
+// allocating. It stops as soon as fn returns false. fn is not called for the
+// empty name.
+func (n Name) WalkUp(fn func(Name) bool) {
+	if n.value == "" {
+		return
+	}
+	for cur, ok := n, true; ok; cur, ok = cur.Parent() {
+		if !fn(cur) {
+			return
+		}
+	}
+}
+
+// TrimToDepth returns the logical cluster name truncated to its first depth
+// segments, e.g. "root:a" for "root:a:b:c" and depth 2. Names with at most depth
+// segments are returned unchanged, and a depth of zero or less returns the empty
+// name.
+func (n Name) TrimToDepth(depth int) Name {
+	if depth <= 0 {
+		return Name{}
+	}
+	for i := 0; i < len(n.value); i++ {
+		if n.value[i] == separator[0] {
+			if depth--; depth == 0 {
+				return Name{n.value[:i]}
+			}
+		}
+	}
+	return n
+}
+
+// Tail returns the logical cluster name without its first segment, e.g. "a:b"
+// for "root:a:b". It returns false for the empty name and names with a single
+// segment, including Wildcard.
+func (n Name) Tail() (Name, bool) {
+	_, tail, ok := strings.Cut(n.value, separator)
+	return Name{tail}, ok
+}
+
 // Split splits logical cluster immediately following the final colon,
 // separating it into a parent logical cluster and name component.
 // If there is no colon in path, Split returns an empty logical cluster name
@@ -119,37 +363,765 @@ func (n Name) Base() string {
 	return name
 }
 
-// Join joins a parent logical cluster name and a name component.
-func (n Name) Join(name string) Name {
+// Segments returns the colon separated components of the logical cluster name,
+// from the root to the last component. The empty name has no segments.
+func (n Name) Segments() []string {
+	if n.value == "" {
+		return []string{}
+	}
+	return strings.Split(n.value, separator)
+}
+
+// SplitN is like Segments, but splits into at most count segments with the
+// unsplit remainder as the last one, analogous to strings.SplitN. If count is
+// zero, it returns nil, and if count is negative, all segments are returned.
+// The empty name has no segments.
+func (n Name) SplitN(count int) []string {
+	if n.value == "" && count != 0 {
+		return []string{}
+	}
+	return strings.SplitN(n.value, separator, count)
+}
+
+// CountSegment returns how often name occurs as a segment of the logical cluster
+// name, e.g. 2 for "a" in "a:b:a", but 0 for "a" in "ab:ba".
+func (n Name) CountSegment(name string) int {
+	if n.value == "" {
+		return 0
+	}
+	count := 0
+	for rest, more := n.value, true; more; {
+		var segment string
+		segment, rest, more = strings.Cut(rest, separator)
+		if segment == name {
+			count++
+		}
+	}
+	return count
+}
+
+// ContainsSegment returns true if name is any segment of the logical cluster
+// name, e.g. true for "account" in "root:account:a", but false in
+// "root:accounting".
+func (n Name) ContainsSegment(name string) bool {
+	if n.value == "" {
+		return false
+	}
+	for rest, more := n.value, true; more; {
+		var segment string
+		segment, rest, more = strings.Cut(rest, separator)
+		if segment == name {
+			return true
+		}
+	}
+	return false
+}
+
+// HasCycle returns true if any segment occurs more than once in the logical
+// cluster name, e.g. for "root:a:root", which indicates a cycle in a naive
+// hierarchy. This is advisory only, as some naming schemes legitimately repeat
+// segment names.
+func (n Name) HasCycle() bool {
+	segments := n.Segments()
+	seen := make(map[string]struct{}, len(segments))
+	for _, segment := range segments {
+		if _, found := seen[segment]; found {
+			return true
+		}
+		seen[segment] = struct{}{}
+	}
+	return false
+}
+
+// SegmentAt returns the segment at index i, counting from 0 for the root. A
+// negative index counts from the end, i.e. -1 is the last segment. It returns
+// false if i is out of range.
+func (n Name) SegmentAt(i int) (string, bool) {
+	if i < 0 {
+		i += n.Depth()
+	}
+	if i < 0 || n.value == "" {
+		return "", false
+	}
+	rest := n.value
+	for ; i > 0; i-- {
+		var more bool
+		if _, rest, more = strings.Cut(rest, separator); !more {
+			return "", false
+		}
+	}
+	segment, _, _ := strings.Cut(rest, separator)
+	return segment, true
+}
+
+// Reverse returns the logical cluster name with its segments in reverse order,
+// e.g. "b:a:root" for "root:a:b".
+func (n Name) Reverse() Name {
+	segments := n.Segments()
+	for i, j := 0, len(segments)-1; i < j; i, j = i+1, j-1 {
+		segments[i], segments[j] = segments[j], segments[i]
+	}
+	return Name{strings.Join(segments, separator)}
+}
+
+// Depth returns the number of segments of the logical cluster name, i.e. 0 for
+// the empty name, 1 for "foo" and 3 for "foo:bar:baz". Wildcard has depth 1.
+// Empty segments are counted, i.e. "foo:" has depth 2.
+func (n Name) Depth() int {
 	if n.value == "" {
-		return Name{name}
+		return 0
 	}
-	return Name{n.value + separator + name}
+	return strings.Count(n.value, separator) + 1
 }
 
+// Root returns the first component of the logical cluster name as a name on
+// its own. It returns false for the empty name. For names with a single
+// segment, including Wildcard, Root returns the name itself.
+func (n Name) Root() (Name, bool) {
+	root, _, _ := strings.Cut(n.value, separator)
+	return Name{root}, n.value != ""
+}
+
+// HasRootSegment returns true if the first segment of the logical cluster name
+// equals s exactly, i.e. case-sensitive and on segment boundaries.
+func (n Name) HasRootSegment(s string) bool {
+	root, ok := n.Root()
+	return ok && root.value == s
+}
+
+// IsSystem returns true if the logical cluster name is "system" or is below it,
+// i.e. denotes an internal logical cluster.
+func (n Name) IsSystem() bool {
+	return n.HasRootSegment("system")
+}
+
+// LeafName returns the last component of the logical cluster name as a name on
+// its own, e.g. "b" for "root:a:b". It returns false if the last component is
+// not a valid logical cluster name, including for the empty name and Wildcard.
+func (n Name) LeafName() (Name, bool) {
+	leaf := n.Base()
+	if !isValidName(leaf) {
+		return Name{}, false
+	}
+	return Name{leaf}, true
+}
+
+// IsHashSegment returns true if s has the form of a hashed root segment, i.e.
+// consists of exactly 8 lower-case hexadecimal digits like "62208dab". Note that
+// human-readable names of that form, like "deadbeef", are indistinguishable
+// from hashes.
+func IsHashSegment(s string) bool {
+	if len(s) != 8 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// RootIsHash returns true if the first segment of the logical cluster name is a
+// hashed root segment as defined by IsHashSegment.
+func (n Name) RootIsHash() bool {
+	root, _ := n.Root()
+	return IsHashSegment(root.value)
+}
+
+// Join joins a parent logical cluster name and one or more name components.
+// Without components, n is returned unchanged.
+func (n Name) Join(names ...string) Name {
+	switch {
+	case len(names) == 0:
+		return n
+	case len(names) == 1 && n.value == "":
+		return Name{names[0]}
+	case len(names) == 1:
+		return Name{n.value + separator + names[0]}
+	}
+
+	// join all components with a single allocation
+	size := len(separator) * (len(names) - 1)
+	if n.value != "" {
+		size += len(n.value) + len(separator)
+	}
+	for _, name := range names {
+		size += len(name)
+	}
+	var b strings.Builder
+	b.Grow(size)
+	if n.value != "" {
+		b.WriteString(n.value)
+		b.WriteString(separator)
+	}
+	for i, name := range names {
+		if i > 0 {
+			b.WriteString(separator)
+		}
+		b.WriteString(name)
+	}
+	return Name{b.String()}
+}
+
+// Append returns the concatenation of the logical cluster names n and other,
+// e.g. "a:b" for "a" and "b". If either is empty, the other is returned.
+func (n Name) Append(other Name) Name {
+	switch {
+	case n.value == "":
+		return other
+	case other.value == "":
+		return n
+	}
+	return Name{n.value + separator + other.value}
+}
+
+// JoinSegment joins a parent logical cluster name and a single name component
+// like Join, but returns an error if the component contains a colon and hence
+// would add more than one level to the hierarchy.
+func (n Name) JoinSegment(name string) (Name, error) {
+	if strings.Contains(name, separator) {
+		return n, fmt.Errorf("logical cluster name component %q must not contain %q", name, separator)
+	}
+	return n.Join(name), nil
+}
+
+// JoinNormalized joins a parent logical cluster name and a single name component
+// like JoinSegment, after trimming surrounding white space from the component
+// and lower-casing it, e.g. to accept interactive user input. It returns an
+// error if the cleaned component is still not a valid single segment.
+func (n Name) JoinNormalized(name string) (Name, error) {
+	clean := New(strings.TrimSpace(name)).ToLower().value
+	joined, err := n.JoinSegment(clean)
+	if err != nil {
+		return n, err
+	}
+	if !isValidName(clean) {
+		return n, &InvalidNameError{Value: clean, Reason: invalidReason(clean)}
+	}
+	return joined, nil
+}
+
+// JoinValid joins a parent logical cluster name and a name component like Join,
+// but only if the result is a valid logical cluster name. Otherwise, n is
+// returned unchanged together with false.
+func (n Name) JoinValid(name string) (Name, bool) {
+	joined := n.Join(name)
+	if !joined.IsValid() {
+		return n, false
+	}
+	return joined, true
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (n Name) MarshalText() ([]byte, error) {
+	return []byte(n.value), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts the empty name
+// and valid logical cluster names only, and returns an *InvalidNameError
+// otherwise.
+func (n *Name) UnmarshalText(text []byte) error {
+	s := string(text)
+	if s != "" {
+		if err := New(s).Validate(); err != nil {
+			return err
+		}
+	}
+	n.value = s
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, encoding the name as its
+// UTF-8 string representation.
+func (n Name) MarshalBinary() ([]byte, error) {
+	return n.MarshalText()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. Like UnmarshalText, it
+// accepts the empty name and valid logical cluster names only.
+func (n *Name) UnmarshalBinary(data []byte) error {
+	return n.UnmarshalText(data)
+}
+
+// MarshalJSON implements json.Marshaler, encoding the name as a JSON string.
 func (n Name) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&n.value)
 }
 
+// UnmarshalJSON implements json.Unmarshaler. Like UnmarshalText, it accepts the
+// empty name and valid logical cluster names only. null decodes to the empty name.
 func (n *Name) UnmarshalJSON(data []byte) error {
 	var s string
 	if err := json.Unmarshal(data, &s); err != nil {
 		return err
 	}
-	n.value = s
-	return nil
+	return n.UnmarshalText([]byte(s))
 }
 
+// HasPrefix returns true if other is a prefix of the logical cluster name on
+// segment boundaries, i.e. every segment of other equals the segment of n at the
+// same position. The empty name is a prefix of every name, and every name is a
+// prefix of itself. Wildcard is compared like any other segment.
 func (n Name) HasPrefix(other Name) bool {
-	return strings.HasPrefix(n.value, other.value)
+	if other.value == "" {
+		return true
+	}
+	if !strings.HasPrefix(n.value, other.value) {
+		return false
+	}
+	return len(n.value) == len(other.value) || n.value[len(other.value)] == separator[0]
+}
+
+// IsAncestorOf returns true if n is a proper ancestor of other, i.e. other has n
+// as prefix on segment boundaries, but is not equal to n. The empty name is an
+// ancestor of every non-empty name.
+func (n Name) IsAncestorOf(other Name) bool {
+	return n.value != other.value && other.HasPrefix(n)
+}
+
+// IsDescendantOf returns true if n is a proper descendant of other, i.e. other
+// is a proper ancestor of n.
+func (n Name) IsDescendantOf(other Name) bool {
+	return other.IsAncestorOf(n)
 }
 
-const lclusterNameFmt string = "[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?"
+// RelativeTo returns the remainder of the logical cluster name after base, e.g.
+// "a:b" for "root:a:b" relative to "root". It returns false if base is not a
+// prefix of n on segment boundaries. If n equals base, the remainder is empty.
+func (n Name) RelativeTo(base Name) (Name, bool) {
+	if !n.HasPrefix(base) {
+		return Name{}, false
+	}
+	if base.value == "" || len(n.value) == len(base.value) {
+		return Name{n.value[len(base.value):]}, true
+	}
+	return Name{n.value[len(base.value)+1:]}, true
+}
 
-var lclusterRegExp = regexp.MustCompile("^" + lclusterNameFmt + "(:" + lclusterNameFmt + ")*$")
+// TrimPrefix returns the logical cluster name without the leading prefix
+// segments, e.g. "team:app" for "root:team:app" with prefix "root". If prefix
+// is not a prefix of n on segment boundaries, n is returned unchanged.
+func (n Name) TrimPrefix(prefix Name) Name {
+	if rel, ok := n.RelativeTo(prefix); ok {
+		return rel
+	}
+	return n
+}
+
+// ReplacePrefix replaces the leading segments old of the logical cluster name
+// with new, e.g. "mgmt:b" for "root:a:b" with old "root:a" and new "mgmt". An
+// empty new trims old. It returns n unchanged and false if old is not a prefix
+// of n on segment boundaries, or if the result is not a valid logical cluster
+// name.
+func (n Name) ReplacePrefix(old, new Name) (Name, bool) {
+	rel, ok := n.RelativeTo(old)
+	if !ok {
+		return n, false
+	}
+	replaced := new
+	if rel.value != "" {
+		replaced = new.Join(rel.value)
+	}
+	if !replaced.IsValid() {
+		return n, false
+	}
+	return replaced, true
+}
+
+// Matches returns true if the logical cluster name matches pattern segment by
+// segment. A "*" segment in pattern matches exactly one arbitrary non-empty
+// segment, a "**" segment matches zero or more arbitrary non-empty segments, and
+// all other segments must be equal. E.g. "root:*:invoices" matches
+// "root:a:invoices", and "root:**" matches "root", "root:a" and "root:a:b".
+func (n Name) Matches(pattern Name) bool {
+	return matchSegments(n.Segments(), pattern.Segments())
+}
+
+func matchSegments(segments, patterns []string) bool {
+	for len(patterns) > 0 {
+		switch patterns[0] {
+		case "**":
+			for i := 0; i <= len(segments); i++ {
+				if i > 0 && segments[i-1] == "" {
+					break
+				}
+				if matchSegments(segments[i:], patterns[1:]) {
+					return true
+				}
+			}
+			return false
+		case "*":
+			if len(segments) == 0 || segments[0] == "" {
+				return false
+			}
+		default:
+			if len(segments) == 0 || segments[0] != patterns[0] {
+				return false
+			}
+		}
+		segments, patterns = segments[1:], patterns[1:]
+	}
+	return len(segments) == 0
+}
+
+// IsChildOf returns true if parent is the immediate parent of the logical
+// cluster name as returned by Parent, e.g. "root:a" for "root:a:b", but not
+// "root". As names with a single segment have no parent, they are not a child
+// of the empty name.
+func (n Name) IsChildOf(parent Name) bool {
+	p, ok := n.Parent()
+	return ok && p == parent
+}
+
+// CommonAncestor returns the longest logical cluster name that is a prefix of
+// both a and b on segment boundaries, i.e. "root:a" for "root:a:b" and
+// "root:a:c", and "root" for "root:ab" and "root:a". If a and b do not share
+// the root segment, the empty name is returned.
+func CommonAncestor(a, b Name) Name {
+	x, y := a.value, b.value
+	common := 0
+	for i := 0; ; i++ {
+		endX := i == len(x) || x[i] == separator[0]
+		endY := i == len(y) || y[i] == separator[0]
+		if endX && endY {
+			common = i
+			if i == len(x) || i == len(y) {
+				break
+			}
+			continue
+		}
+		if endX || endY || x[i] != y[i] {
+			break
+		}
+	}
+	return Name{x[:common]}
+}
+
+// HasSuffix returns true if other is a suffix of the logical cluster name on
+// segment boundaries, i.e. every segment of other equals the segment of n at the
+// same position counted from the end. The empty name is a suffix of every name,
+// and every name is a suffix of itself.
+func (n Name) HasSuffix(other Name) bool {
+	if other.value == "" {
+		return true
+	}
+	if !strings.HasSuffix(n.value, other.value) {
+		return false
+	}
+	i := len(n.value) - len(other.value)
+	return i == 0 || n.value[i-1] == separator[0]
+}
+
+// TrimSuffix returns the logical cluster name without the trailing suffix
+// segments, e.g. "root:a" for "root:a:invoices" with suffix "invoices". If
+// suffix is empty or not a suffix of n on segment boundaries, n is returned
+// unchanged. If suffix equals n, the empty name is returned.
+func (n Name) TrimSuffix(suffix Name) Name {
+	if suffix.value == "" || !n.HasSuffix(suffix) {
+		return n
+	}
+	i := len(n.value) - len(suffix.value)
+	if i == 0 {
+		return Name{}
+	}
+	return Name{n.value[:i-1]}
+}
+
+// Normalize returns the logical cluster name with repeated colons collapsed and
+// leading and trailing colons removed, e.g. "foo:baz" for "foo::baz:". It does
+// not change the case or otherwise validate the segments.
+func (n Name) Normalize() Name {
+	if !strings.HasPrefix(n.value, separator) && !strings.HasSuffix(n.value, separator) && !strings.Contains(n.value, separator+separator) {
+		return n
+	}
+	segments := strings.Split(n.value, separator)
+	nonEmpty := segments[:0]
+	for _, segment := range segments {
+		if segment != "" {
+			nonEmpty = append(nonEmpty, segment)
+		}
+	}
+	return Name{strings.Join(nonEmpty, separator)}
+}
+
+// Canonical returns the canonical form of the logical cluster name, i.e. its
+// normalized form as returned by Normalize. Names that are spelled differently,
+// but denote the same hierarchy, like "foo:" and "foo", have the same canonical
+// form. Use it before using names as map keys.
+func (n Name) Canonical() Name {
+	return n.Normalize()
+}
+
+// NewCanonical returns the canonical Name from a string.
+func NewCanonical(value string) Name {
+	return New(value).Canonical()
+}
+
+// ToLower returns the logical cluster name with all ASCII upper-case letters
+// mapped to lower-case, e.g. to normalize user input before validation. Other
+// characters, including non-ASCII letters, are left unchanged.
+func (n Name) ToLower() Name {
+	i := strings.IndexFunc(n.value, func(r rune) bool { return r >= 'A' && r <= 'Z' })
+	if i < 0 {
+		return n
+	}
+	b := []byte(n.value)
+	for ; i < len(b); i++ {
+		if c := b[i]; c >= 'A' && c <= 'Z' {
+			b[i] = c + 'a' - 'A'
+		}
+	}
+	return Name{string(b)}
+}
+
+// Equal returns true if the logical cluster names are identical. The comparison is
+// exact and case-sensitive, and no normalization is applied, i.e. "foo:" does not
+// equal "foo". Wildcard only equals Wildcard.
+func (n Name) Equal(other Name) bool {
+	return n.value == other.value
+}
+
+// EqualFold returns true if the logical cluster names are equal under Unicode
+// case-folding, e.g. "Root:Accounting" and "root:accounting". It does not imply
+// that either name is valid.
+func (n Name) EqualFold(other Name) bool {
+	return strings.EqualFold(n.value, other.value)
+}
+
+// EqualIgnoringRoot returns true if the logical cluster names have the same
+// segments after the first one, e.g. "root:a:b" and "62208dab:a:b", which may
+// address the same logical cluster through a name root and a hashed root. Two
+// single-segment names are equal ignoring their roots, but the empty name is
+// only equal to itself.
+func (n Name) EqualIgnoringRoot(other Name) bool {
+	tail, ok := n.Tail()
+	otherTail, otherOK := other.Tail()
+	return n.Empty() == other.Empty() && ok == otherOK && tail == otherTail
+}
+
+// Diff returns a human-readable description of how the logical cluster name
+// differs from other, e.g. `diverges at segment 2: got "b", want "c"` for
+// "root:a:b" and "root:a:c", with segments counted from 0. It returns the empty
+// string if the names are equal.
+func (n Name) Diff(other Name) string {
+	if n.value == other.value {
+		return ""
+	}
+	got, want := n.Segments(), other.Segments()
+	i := 0
+	for i < len(got) && i < len(want) && got[i] == want[i] {
+		i++
+	}
+	describe := func(segments []string) string {
+		if i >= len(segments) {
+			return "end of name"
+		}
+		return strconv.Quote(segments[i])
+	}
+	return fmt.Sprintf("diverges at segment %d: got %s, want %s", i, describe(got), describe(want))
+}
+
+// Compare compares two logical cluster names segment by segment. The result is 0
+// if n equals other, negative if n sorts before other, and positive otherwise.
+// Segments on the same level are compared lexically, and an ancestor sorts
+// before its descendants, i.e. "root:a" < "root:a:z" < "root:ab". The empty
+// name sorts before every other name.
+func (n Name) Compare(other Name) int {
+	a, b := n.value, other.value
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return -1
+	case b == "":
+		return 1
+	}
+	for {
+		segA, restA, moreA := strings.Cut(a, separator)
+		segB, restB, moreB := strings.Cut(b, separator)
+		if c := strings.Compare(segA, segB); c != 0 {
+			return c
+		}
+		switch {
+		case !moreA && !moreB:
+			return 0
+		case !moreA:
+			return -1
+		case !moreB:
+			return 1
+		}
+		a, b = restA, restB
+	}
+}
+
+// Less returns true if n sorts before other according to Compare.
+func (n Name) Less(other Name) bool {
+	return n.Compare(other) < 0
+}
+
+// NameSlice attaches the methods of sort.Interface to []Name, sorting in
+// hierarchical order as defined by Name.Compare.
+type NameSlice []Name
+
+func (s NameSlice) Len() int           { return len(s) }
+func (s NameSlice) Less(i, j int) bool { return s[i].Less(s[j]) }
+func (s NameSlice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// InvalidNameError describes why a string is not a valid logical cluster name.
+type InvalidNameError struct {
+	// Value is the offending string.
+	Value string
+	// Reason is a human-readable description of the first problem found.
+	Reason string
+}
+
+func (e *InvalidNameError) Error() string {
+	return fmt.Sprintf("invalid logical cluster name %q: %s", e.Value, e.Reason)
+}
+
+// ParseName returns a Name from a string, or an *InvalidNameError describing why
+// the string is not a valid logical cluster name.
+func ParseName(value string) (Name, error) {
+	n := Name{value}
+	if err := n.Validate(); err != nil {
+		return Name{}, err
+	}
+	return n, nil
+}
+
+// MustParseName is like ParseName, but panics if value is not a valid logical
+// cluster name. It simplifies safe initialization of variables and test
+// fixtures holding names.
+func MustParseName(value string) Name {
+	n, err := ParseName(value)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+// MustNew is like New, but panics like MustParseName if value is not a valid
+// logical cluster name.
+func MustNew(value string) Name {
+	return MustParseName(value)
+}
+
+// Validate returns nil if the name is valid as defined by IsValid, or an
+// *InvalidNameError describing the first problem found.
+func (n Name) Validate() error {
+	if n.IsValid() {
+		return nil
+	}
+	return &InvalidNameError{Value: n.value, Reason: invalidReason(n.value)}
+}
+
+// ValidateNames validates all names and returns an error for each invalid one,
+// prefixed with its index and wrapping the *InvalidNameError returned by
+// Validate. It returns nil if all names are valid.
+func ValidateNames(names []Name) []error {
+	var errs []error
+	for i, n := range names {
+		if err := n.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("[%d]: %w", i, err))
+		}
+	}
+	return errs
+}
+
+// ValidateDepth is like Validate, but additionally enforces that the name has at
+// most max segments. A max of zero or less means unlimited depth.
+func (n Name) ValidateDepth(max int) error {
+	if err := n.Validate(); err != nil {
+		return err
+	}
+	if depth := n.Depth(); max > 0 && depth > max {
+		return &InvalidNameError{Value: n.value, Reason: fmt.Sprintf("depth %d exceeds maximum depth %d", depth, max)}
+	}
+	return nil
+}
+
+// DefaultMaxLength is a suggested maximum for ValidateMaxLength. It leaves
+// enough room for deep hierarchies while keeping etcd keys and request URLs
+// well below common size limits.
+const DefaultMaxLength = 1024
+
+// ValidateMaxLength is like Validate, but additionally enforces that the name
+// is at most max bytes long. A max of zero or less means unlimited length.
+func (n Name) ValidateMaxLength(max int) error {
+	if err := n.Validate(); err != nil {
+		return err
+	}
+	if length := len(n.value); max > 0 && length > max {
+		return &InvalidNameError{Value: n.value, Reason: fmt.Sprintf("length %d exceeds maximum length %d", length, max)}
+	}
+	return nil
+}
+
+// invalidReason returns a description of the first reason why isValidName
+// rejects value, or the empty string if it does not.
+func invalidReason(value string) string {
+	if value == "" {
+		return "must not be empty"
+	}
+	offset := 0
+	for _, segment := range strings.Split(value, separator) {
+		if segment == "" {
+			return fmt.Sprintf("empty segment at index %d", offset)
+		}
+		for i, r := range segment {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return fmt.Sprintf("illegal character %q at index %d", r, offset+i)
+			}
+		}
+		switch {
+		case segment[0] == '-':
+			return fmt.Sprintf("segment %q must not start with a hyphen", segment)
+		case segment[len(segment)-1] == '-':
+			return fmt.Sprintf("segment %q must not end with a hyphen", segment)
+		case len(segment) > maxSegmentLength:
+			return fmt.Sprintf("segment %q is longer than %d characters", segment, maxSegmentLength)
+		}
+		offset += len(segment) + len(separator)
+	}
+	return ""
+}
 
 // IsValid returns true if the name is a Wildcard or a colon separated list of words where each word
 // starts with a lower-case letter and contains only lower-case letters, digits and hyphens.
 func (n Name) IsValid() bool {
-	return n == Wildcard || lclusterRegExp.MatchString(n.value)
+	return n.IsWildcard() || isValidName(n.value)
+}
+
+// IsValidStrict returns true if the name is valid and denotes a concrete logical
+// cluster that can be used as etcd key and URL path segment. In addition to the
+// rules of IsValid, it rejects the reserved values
+//   - "*", i.e. Wildcard, which denotes all logical clusters,
+//   - "." and "..", which have special meaning in paths. These are already
+//     rejected by IsValid as dots are not allowed.
+func (n Name) IsValidStrict() bool {
+	return !n.IsWildcard() && n.IsValid()
+}
+
+// maxSegmentLength is the maximum length of a segment of a logical cluster name.
+const maxSegmentLength = 63
+
+// isValidName returns true if value is a colon separated list of segments, each
+// matching [a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?. It is a hand-written scanner
+// equivalent to the regular expression as IsValid is called in hot paths.
+func isValidName(value string) bool {
+	start := 0
+	for i := 0; i <= len(value); i++ {
+		if i < len(value) && value[i] != separator[0] {
+			if c := value[i]; (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+				return false
+			}
+			continue
+		}
+		if l := i - start; l == 0 || l > maxSegmentLength || value[start] == '-' || value[i-1] == '-' {
+			return false
+		}
+		start = i + 1
+	}
+	return true
 }
@@ -17,7 +17,16 @@ limitations under the License.
 package logicalcluster
 
 import (
+	"bytes"
+	"encoding/gob"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
 	"testing"
 )
 
@@ -46,6 +55,33 @@ func TestName_Split(t *testing.T) {
 	}
 }
 
+func TestName_SplitN(t *testing.T) {
+	tests := []struct {
+		name Name
+		n    int
+		want []string
+	}{
+		{New(""), 2, []string{}},
+		{New(""), -1, []string{}},
+		{New(""), 0, nil},
+		{New("root:a:b:c"), 0, nil},
+		{New("root:a:b:c"), 1, []string{"root:a:b:c"}},
+		{New("root:a:b:c"), 2, []string{"root", "a:b:c"}},
+		{New("root:a:b:c"), 3, []string{"root", "a", "b:c"}},
+		{New("root:a:b:c"), 4, []string{"root", "a", "b", "c"}},
+		{New("root:a:b:c"), 5, []string{"root", "a", "b", "c"}},
+		{New("root:a:b:c"), -1, []string{"root", "a", "b", "c"}},
+		{New("root::b"), 2, []string{"root", ":b"}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/%d", tt.name, tt.n), func(t *testing.T) {
+			if got := tt.name.SplitN(tt.n); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q.SplitN(%d) = %#v, want %#v", tt.name, tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestJSON(t *testing.T) {
 	type container struct {
 		Name Name `json:"name"`
@@ -72,6 +108,10 @@ func TestJSON(t *testing.T) {
 	}
 }
 
+// lclusterRegExp is the reference definition of valid logical cluster names,
+// implemented by isValidName.
+var lclusterRegExp = regexp.MustCompile("^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?(:[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)*$")
+
 func TestIsValidCluster(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -109,6 +149,2111 @@ func TestIsValidCluster(t *testing.T) {
 			if got := New(tt.name).IsValid(); got != tt.valid {
 				t.Errorf("isValid(%q) = %v, want %v", tt.name, got, tt.valid)
 			}
+			if got := New(tt.name).Validate() == nil; got != tt.valid {
+				t.Errorf("Validate(%q) = %v, want valid %v", tt.name, New(tt.name).Validate(), tt.valid)
+			}
+		})
+	}
+}
+
+func TestName_HasPrefix(t *testing.T) {
+	tests := []struct {
+		name   Name
+		prefix Name
+		want   bool
+	}{
+		{New(""), New(""), true},
+		{New("root"), New(""), true},
+		{New(""), New("root"), false},
+		{New("root"), New("root"), true},
+		{New("root:accounting"), New("root"), true},
+		{New("root:accounting"), New("root:accounting"), true},
+		{New("root:accounting"), New("root:acc"), false},
+		{New("root:acc"), New("root:accounting"), false},
+		{New("root:accounting:us-west"), New("root:accounting"), true},
+		{New("rootx:accounting"), New("root"), false},
+		{New("root"), New("root:accounting"), false},
+		{New("foo::bar"), New("foo:"), true},
+		{New("foo:bar"), New("foo:"), false},
+		{Wildcard, Wildcard, true},
+		{Wildcard, New(""), true},
+		{New("root"), Wildcard, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String()+"/"+tt.prefix.String(), func(t *testing.T) {
+			if got := tt.name.HasPrefix(tt.prefix); got != tt.want {
+				t.Errorf("%q.HasPrefix(%q) = %v, want %v", tt.name, tt.prefix, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestName_Equal(t *testing.T) {
+	tests := []struct {
+		a, b Name
+		want bool
+	}{
+		{New(""), New(""), true},
+		{New(""), None, true},
+		{New(""), New("root"), false},
+		{Wildcard, Wildcard, true},
+		{Wildcard, New("*"), true},
+		{Wildcard, New("root"), false},
+		{New("root:a"), New("root:a"), true},
+		{New("root:a"), New("root:b"), false},
+		{New("foo:"), New("foo"), false},
+		{New("Root:A"), New("root:a"), false},
+		{New("Root:A"), New("Root:A"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a.String()+"/"+tt.b.String(), func(t *testing.T) {
+			if got := tt.a.Equal(tt.b); got != tt.want {
+				t.Errorf("%q.Equal(%q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+			if got := tt.b.Equal(tt.a); got != tt.want {
+				t.Errorf("%q.Equal(%q) = %v, want %v", tt.b, tt.a, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestName_Compare(t *testing.T) {
+	// in expected order
+	names := []Name{
+		New(""),
+		Wildcard,
+		New("root"),
+		New("root:"),
+		New("root:a"),
+		New("root:a:b"),
+		New("root:a:z"),
+		New("root:ab"),
+		New("root:b"),
+		New("root-a"),
+	}
+	for i, a := range names {
+		for j, b := range names {
+			want := 0
+			switch {
+			case i < j:
+				want = -1
+			case i > j:
+				want = 1
+			}
+			got := a.Compare(b)
+			switch {
+			case got < 0:
+				got = -1
+			case got > 0:
+				got = 1
+			}
+			if got != want {
+				t.Errorf("%q.Compare(%q) = %d, want %d", a, b, got, want)
+			}
+			if got := a.Less(b); got != (want < 0) {
+				t.Errorf("%q.Less(%q) = %v, want %v", a, b, got, want < 0)
+			}
+		}
+	}
+}
+
+func TestNameSlice(t *testing.T) {
+	names := NameSlice{
+		New("root:b"),
+		New("root:a:b"),
+		Wildcard,
+		New("root"),
+		New(""),
+		New("root:ab"),
+		New("root:a"),
+		New("root"),
+	}
+	sort.Sort(names)
+
+	expected := NameSlice{
+		New(""),
+		Wildcard,
+		New("root"),
+		New("root"),
+		New("root:a"),
+		New("root:a:b"),
+		New("root:ab"),
+		New("root:b"),
+	}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("sort.Sort() = %v, want %v", names, expected)
+	}
+}
+
+func TestName_Segments(t *testing.T) {
+	tests := []struct {
+		name Name
+		want []string
+	}{
+		{New(""), []string{}},
+		{New("root"), []string{"root"}},
+		{Wildcard, []string{"*"}},
+		{New("root:accounting:us-west"), []string{"root", "accounting", "us-west"}},
+		{New("foo::bar"), []string{"foo", "", "bar"}},
+		{New("foo:"), []string{"foo", ""}},
+		{New(":foo"), []string{"", "foo"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String(), func(t *testing.T) {
+			if got := tt.name.Segments(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q.Segments() = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestName_Depth(t *testing.T) {
+	tests := []struct {
+		name Name
+		want int
+	}{
+		{New(""), 0},
+		{Wildcard, 1},
+		{New("foo"), 1},
+		{New("foo:bar"), 2},
+		{New("foo:bar:baz"), 3},
+		{New("foo:"), 2},
+		{New("foo::"), 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String(), func(t *testing.T) {
+			if got := tt.name.Depth(); got != tt.want {
+				t.Errorf("%q.Depth() = %d, want %d", tt.name, got, tt.want)
+			}
+			if got, segments := tt.name.Depth(), len(tt.name.Segments()); got != segments {
+				t.Errorf("%q.Depth() = %d, but has %d segments", tt.name, got, segments)
+			}
+		})
+	}
+}
+
+func TestName_Ancestors(t *testing.T) {
+	tests := []struct {
+		name Name
+		want []Name
+	}{
+		{New(""), []Name{}},
+		{Wildcard, []Name{}},
+		{New("root"), []Name{}},
+		{New("root:a"), []Name{New("root")}},
+		{New("root:a:b"), []Name{New("root:a"), New("root")}},
+		{New("root:a:"), []Name{New("root:a"), New("root")}},
+		{New("root:"), []Name{New("root")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String(), func(t *testing.T) {
+			if got := tt.name.Ancestors(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q.Ancestors() = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestName_WalkUp(t *testing.T) {
+	tests := []struct {
+		name  Name
+		limit int
+		want  []Name
+	}{
+		{New(""), -1, nil},
+		{Wildcard, -1, []Name{Wildcard}},
+		{New("root"), -1, []Name{New("root")}},
+		{New("root:a:b"), -1, []Name{New("root:a:b"), New("root:a"), New("root")}},
+		{New("root:a:b"), 1, []Name{New("root:a:b")}},
+		{New("root:a:b"), 2, []Name{New("root:a:b"), New("root:a")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String(), func(t *testing.T) {
+			var got []Name
+			tt.name.WalkUp(func(n Name) bool {
+				got = append(got, n)
+				return len(got) != tt.limit
+			})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q.WalkUp() visited %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommonAncestor(t *testing.T) {
+	tests := []struct {
+		a, b Name
+		want Name
+	}{
+		{New(""), New(""), New("")},
+		{New("root"), New(""), New("")},
+		{New("root"), New("root"), New("root")},
+		{New("root:a:b"), New("root:a:b"), New("root:a:b")},
+		{New("root:a:b"), New("root:a:c"), New("root:a")},
+		{New("root:a:b"), New("root:a"), New("root:a")},
+		{New("root:a"), New("other:x"), New("")},
+		{New("root:ab"), New("root:a"), New("root")},
+		{New("root:ab:c"), New("root:a:c"), New("root")},
+		{New("foo:"), New("foo:"), New("foo:")},
+		{Wildcard, Wildcard, Wildcard},
+		{Wildcard, New("root"), New("")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a.String()+"/"+tt.b.String(), func(t *testing.T) {
+			if got := CommonAncestor(tt.a, tt.b); got != tt.want {
+				t.Errorf("CommonAncestor(%q, %q) = %q, want %q", tt.a, tt.b, got, tt.want)
+			}
+			if got := CommonAncestor(tt.b, tt.a); got != tt.want {
+				t.Errorf("CommonAncestor(%q, %q) = %q, want %q", tt.b, tt.a, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestName_IsAncestorOf(t *testing.T) {
+	tests := []struct {
+		ancestor, descendant Name
+		want                 bool
+	}{
+		{New(""), New(""), false},
+		{New(""), New("root"), true},
+		{New(""), New("root:a:b"), true},
+		{New("root"), New("root"), false},
+		{New("root"), New("root:a"), true},
+		{New("root"), New("root:a:b"), true},
+		{New("root:a"), New("root:a:b"), true},
+		{New("root:a"), New("root:ab"), false},
+		{New("root:a:b"), New("root:a"), false},
+		{New("root:a"), New("other:a:b"), false},
+		{Wildcard, New("root"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ancestor.String()+"/"+tt.descendant.String(), func(t *testing.T) {
+			if got := tt.ancestor.IsAncestorOf(tt.descendant); got != tt.want {
+				t.Errorf("%q.IsAncestorOf(%q) = %v, want %v", tt.ancestor, tt.descendant, got, tt.want)
+			}
+			if got := tt.descendant.IsDescendantOf(tt.ancestor); got != tt.want {
+				t.Errorf("%q.IsDescendantOf(%q) = %v, want %v", tt.descendant, tt.ancestor, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestName_RelativeTo(t *testing.T) {
+	tests := []struct {
+		name, base Name
+		want       Name
+		wantOk     bool
+	}{
+		{New(""), New(""), New(""), true},
+		{New("root"), New(""), New("root"), true},
+		{New("root"), New("root"), New(""), true},
+		{New("root:a:b"), New("root"), New("a:b"), true},
+		{New("root:a:b"), New("root:a"), New("b"), true},
+		{New("root:a:b"), New("root:a:b"), New(""), true},
+		{New("root:ab"), New("root:a"), New(""), false},
+		{New("root:a"), New("root:a:b"), New(""), false},
+		{New("root:a"), New("other"), New(""), false},
+		{New(""), New("root"), New(""), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String()+"/"+tt.base.String(), func(t *testing.T) {
+			got, gotOk := tt.name.RelativeTo(tt.base)
+			if got != tt.want || gotOk != tt.wantOk {
+				t.Errorf("%q.RelativeTo(%q) = (%q, %v), want (%q, %v)", tt.name, tt.base, got, gotOk, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestName_TrimPrefix(t *testing.T) {
+	tests := []struct {
+		name, prefix Name
+		want         Name
+	}{
+		{New(""), New(""), New("")},
+		{New("root:team:app"), New(""), New("root:team:app")},
+		{New("root:team:app"), New("root"), New("team:app")},
+		{New("root:team:app"), New("root:team"), New("app")},
+		{New("root:team:app"), New("root:team:app"), New("")},
+		{New("root:team:app"), New("root:te"), New("root:team:app")},
+		{New("root:team:app"), New("other"), New("root:team:app")},
+		{New("root:team:app"), Wildcard, New("root:team:app")},
+		{Wildcard, Wildcard, New("")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String()+"/"+tt.prefix.String(), func(t *testing.T) {
+			if got := tt.name.TrimPrefix(tt.prefix); got != tt.want {
+				t.Errorf("%q.TrimPrefix(%q) = %q, want %q", tt.name, tt.prefix, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestName_ReplacePrefix(t *testing.T) {
+	tests := []struct {
+		name, old, new Name
+		want           Name
+		wantOk         bool
+	}{
+		{New("root:a:b"), New("root:a"), New("mgmt"), New("mgmt:b"), true},
+		{New("root:a:b"), New("root"), New("mgmt:x"), New("mgmt:x:a:b"), true},
+		{New("root:a:b"), New("root:a:b"), New("mgmt"), New("mgmt"), true},
+		{New("root:a:b"), New(""), New("mgmt"), New("mgmt:root:a:b"), true},
+		{New("root:a:b"), New("root"), New(""), New("a:b"), true},
+		{New("root:a:b"), New("root:a:b"), New(""), New("root:a:b"), false},
+		{New("root:a:b"), New("root:ab"), New("mgmt"), New("root:a:b"), false},
+		{New("root:a:b"), New("other"), New("mgmt"), New("root:a:b"), false},
+		{New("root:a:b"), New("root"), New("Mgmt"), New("root:a:b"), false},
+		{New("root:a:b"), New("root"), New("mgmt:"), New("root:a:b"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String()+"/"+tt.old.String()+"/"+tt.new.String(), func(t *testing.T) {
+			got, gotOk := tt.name.ReplacePrefix(tt.old, tt.new)
+			if got != tt.want || gotOk != tt.wantOk {
+				t.Errorf("%q.ReplacePrefix(%q, %q) = (%q, %v), want (%q, %v)", tt.name, tt.old, tt.new, got, gotOk, tt.want, tt.wantOk)
+			}
 		})
 	}
 }
+
+func TestName_Root(t *testing.T) {
+	tests := []struct {
+		name   Name
+		want   Name
+		wantOk bool
+	}{
+		{New(""), New(""), false},
+		{Wildcard, Wildcard, true},
+		{New("root"), New("root"), true},
+		{New("root:a"), New("root"), true},
+		{New("root:a:b"), New("root"), true},
+		{New(":a"), New(""), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String(), func(t *testing.T) {
+			got, gotOk := tt.name.Root()
+			if got != tt.want || gotOk != tt.wantOk {
+				t.Errorf("%q.Root() = (%q, %v), want (%q, %v)", tt.name, got, gotOk, tt.want, tt.wantOk)
+			}
+			if tt.name.Depth() == 1 && got.String() != tt.name.Base() {
+				t.Errorf("%q.Root() = %q, but Base() = %q", tt.name, got, tt.name.Base())
+			}
+		})
+	}
+}
+
+func TestName_Join(t *testing.T) {
+	tests := []struct {
+		name  Name
+		names []string
+		want  Name
+	}{
+		{New(""), nil, New("")},
+		{New(""), []string{"a"}, New("a")},
+		{New(""), []string{"a", "b", "c"}, New("a:b:c")},
+		{New("root"), nil, New("root")},
+		{New("root"), []string{"a"}, New("root:a")},
+		{New("root"), []string{"a", "b", "c"}, New("root:a:b:c")},
+		{New("root:a"), []string{"b"}, New("root:a:b")},
+		{Wildcard, nil, Wildcard},
+		{Wildcard, []string{"a"}, New("*:a")},
+		{Wildcard, []string{"a", "b"}, New("*:a:b")},
+		{New(""), []string{"", ""}, New(":")},
+		{New("root"), []string{"", "b"}, New("root::b")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String(), func(t *testing.T) {
+			if got := tt.name.Join(tt.names...); got != tt.want {
+				t.Errorf("%q.Join(%q) = %q, want %q", tt.name, tt.names, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestName_JoinValid(t *testing.T) {
+	tests := []struct {
+		name   Name
+		child  string
+		want   Name
+		wantOk bool
+	}{
+		{New(""), "root", New("root"), true},
+		{New("root"), "a", New("root:a"), true},
+		{New("root"), "a-b", New("root:a-b"), true},
+		{New("root"), "0a", New("root:0a"), true},
+		{New("root"), "BAR", New("root"), false},
+		{New("root"), "", New("root"), false},
+		{New(""), "", New(""), false},
+		{New("root"), "-a", New("root"), false},
+		{New("root"), "a-", New("root"), false},
+		{New("root"), "a_b", New("root"), false},
+		{New("Root"), "a", New("Root"), false},
+		{Wildcard, "a", Wildcard, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String()+"/"+tt.child, func(t *testing.T) {
+			got, gotOk := tt.name.JoinValid(tt.child)
+			if got != tt.want || gotOk != tt.wantOk {
+				t.Errorf("%q.JoinValid(%q) = (%q, %v), want (%q, %v)", tt.name, tt.child, got, gotOk, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestJSON_Unmarshal(t *testing.T) {
+	type container struct {
+		Name Name `json:"name"`
+	}
+
+	tests := []struct {
+		raw     string
+		want    Name
+		wantErr bool
+	}{
+		{`{"name":"root:a"}`, New("root:a"), false},
+		{`{"name":"*"}`, Wildcard, false},
+		{`{"name":""}`, New(""), false},
+		{`{"name":null}`, New(""), false},
+		{`{}`, New(""), false},
+		{`{"name":"root:"}`, New(""), true},
+		{`{"name":"Root"}`, New(""), true},
+		{`{"name":42}`, New(""), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			var got container
+			err := json.Unmarshal([]byte(tt.raw), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Name != tt.want {
+				t.Errorf("incorrect unmarshalled name, expected %q, got %q", tt.want, got.Name)
+			}
+		})
+	}
+
+	t.Run("error names value", func(t *testing.T) {
+		var got container
+		err := json.Unmarshal([]byte(`{"name":"root:Bad"}`), &got)
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		if expected := `invalid logical cluster name "root:Bad": illegal character 'B' at index 5`; err.Error() != expected {
+			t.Errorf("incorrect error, expected %s, got %s", expected, err)
+		}
+		var invalid *InvalidNameError
+		if !errors.As(err, &invalid) {
+			t.Errorf("expected *InvalidNameError, got %T", err)
+		}
+	})
+
+	t.Run("null resets", func(t *testing.T) {
+		n := New("root")
+		if err := n.UnmarshalJSON([]byte("null")); err != nil {
+			t.Fatal(err)
+		}
+		if !n.Empty() {
+			t.Errorf("expected empty name, got %q", n)
+		}
+	})
+}
+
+func TestText(t *testing.T) {
+	for _, name := range []Name{New(""), Wildcard, New("root"), New("root:a:b")} {
+		t.Run(name.String(), func(t *testing.T) {
+			// map keys are encoded via encoding.TextMarshaler
+			initial := map[Name]string{name: "value"}
+			raw, err := json.Marshal(initial)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actual, expected := string(raw), `{"`+name.String()+`":"value"}`; actual != expected {
+				t.Fatalf("incorrect marshalled bytes, expected %s, got %s", expected, actual)
+			}
+
+			var final map[Name]string
+			if err := json.Unmarshal(raw, &final); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(initial, final) {
+				t.Fatalf("incorrect unmarshalled map, expected %v, got %v", initial, final)
+			}
+		})
+	}
+
+	for _, tt := range []struct {
+		invalid, reason string
+	}{
+		{"Root", "illegal character 'R' at index 0"},
+		{"root:", "empty segment at index 5"},
+		{"root::a", "empty segment at index 5"},
+		{"root/a", "illegal character '/' at index 4"},
+	} {
+		t.Run(tt.invalid, func(t *testing.T) {
+			var n Name
+			err := n.UnmarshalText([]byte(tt.invalid))
+			if err == nil {
+				t.Fatalf("expected error unmarshalling %q", tt.invalid)
+			}
+			if expected := `invalid logical cluster name "` + tt.invalid + `": ` + tt.reason; err.Error() != expected {
+				t.Errorf("incorrect error, expected %s, got %s", expected, err)
+			}
+			var invalid *InvalidNameError
+			if !errors.As(err, &invalid) {
+				t.Errorf("expected *InvalidNameError, got %T", err)
+			}
+		})
+	}
+}
+
+func TestBinary(t *testing.T) {
+	type container struct {
+		Name Name
+	}
+
+	for _, name := range []Name{New(""), Wildcard, New("root:a:b")} {
+		t.Run(name.String(), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := gob.NewEncoder(&buf).Encode(container{Name: name}); err != nil {
+				t.Fatal(err)
+			}
+			var final container
+			if err := gob.NewDecoder(&buf).Decode(&final); err != nil {
+				t.Fatal(err)
+			}
+			if final.Name != name {
+				t.Errorf("incorrect decoded name, expected %q, got %q", name, final.Name)
+			}
+		})
+	}
+
+	var n Name
+	if err := n.UnmarshalBinary([]byte("root:")); err == nil {
+		t.Errorf("expected error unmarshalling invalid name")
+	}
+}
+
+func TestGob(t *testing.T) {
+	type container struct {
+		Cluster  Name
+		Parent   Name
+		Empty    Name
+		Wildcard Name
+		Children []Name
+		Pointer  *Name
+	}
+
+	child := New("root:a:b")
+	initial := container{
+		Cluster:  New("root:a"),
+		Parent:   New("root"),
+		Empty:    New(""),
+		Wildcard: Wildcard,
+		Children: []Name{New("root:a:b"), New("root:a:c")},
+		Pointer:  &child,
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(initial); err != nil {
+		t.Fatal(err)
+	}
+	var final container
+	if err := gob.NewDecoder(&buf).Decode(&final); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(initial, final) {
+		t.Errorf("incorrect decoded container, expected %+v, got %+v", initial, final)
+	}
+}
+
+func TestParseRequestPath(t *testing.T) {
+	tests := []struct {
+		path   string
+		want   Name
+		wantOk bool
+	}{
+		{"/clusters/root", New("root"), true},
+		{"/clusters/root:a", New("root:a"), true},
+		{"/clusters/root:a/", New("root:a"), true},
+		{"/clusters/root:a/apis/apps/v1/deployments", New("root:a"), true},
+		{"/clusters/*", Wildcard, true},
+		{"/clusters/*/api/v1/namespaces", Wildcard, true},
+		{"/clusters/", New(""), false},
+		{"/clusters", New(""), false},
+		{"/clusters//api", New(""), false},
+		{"/clusters/root:/api", New(""), false},
+		{"/clusters/Root", New(""), false},
+		{"/apis/apps/v1", New(""), false},
+		{"clusters/root", New(""), false},
+		{"", New(""), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, gotOk := ParseRequestPath(tt.path)
+			if got != tt.want || gotOk != tt.wantOk {
+				t.Errorf("ParseRequestPath(%q) = (%q, %v), want (%q, %v)", tt.path, got, gotOk, tt.want, tt.wantOk)
+			}
+			if gotOk && got.Path() != "/clusters/"+got.String() {
+				t.Errorf("ParseRequestPath(%q) is not inverse of Path(): %q", tt.path, got.Path())
+			}
+		})
+	}
+}
+
+func TestParseName(t *testing.T) {
+	tests := []struct {
+		value   string
+		wantErr string
+	}{
+		{"root", ""},
+		{"root:a-b:0c", ""},
+		{"*", ""},
+		{"", `invalid logical cluster name "": must not be empty`},
+		{"root:", `invalid logical cluster name "root:": empty segment at index 5`},
+		{":root", `invalid logical cluster name ":root": empty segment at index 0`},
+		{"root::a", `invalid logical cluster name "root::a": empty segment at index 5`},
+		{"root:Foo", `invalid logical cluster name "root:Foo": illegal character 'F' at index 5`},
+		{"root:a_b", `invalid logical cluster name "root:a_b": illegal character '_' at index 6`},
+		{"root:föö", `invalid logical cluster name "root:föö": illegal character 'ö' at index 6`},
+		{"root/a", `invalid logical cluster name "root/a": illegal character '/' at index 4`},
+		{"**", `invalid logical cluster name "**": illegal character '*' at index 0`},
+		{"root:-a", `invalid logical cluster name "root:-a": segment "-a" must not start with a hyphen`},
+		{"root:a-", `invalid logical cluster name "root:a-": segment "a-" must not end with a hyphen`},
+		{"root:" + strings.Repeat("a", 64), `invalid logical cluster name "root:` + strings.Repeat("a", 64) + `": segment "` + strings.Repeat("a", 64) + `" is longer than 63 characters`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseName(tt.value)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if got != New(tt.value) {
+					t.Errorf("ParseName(%q) = %q, want %q", tt.value, got, tt.value)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error parsing %q", tt.value)
+			}
+			if err.Error() != tt.wantErr {
+				t.Errorf("incorrect error, expected %s, got %s", tt.wantErr, err)
+			}
+			var invalid *InvalidNameError
+			if !errors.As(err, &invalid) {
+				t.Fatalf("expected *InvalidNameError, got %T", err)
+			}
+			if invalid.Value != tt.value {
+				t.Errorf("incorrect error value, expected %q, got %q", tt.value, invalid.Value)
+			}
+		})
+	}
+}
+
+func TestName_Validate(t *testing.T) {
+	tests := []struct {
+		name       Name
+		wantReason string
+	}{
+		{Wildcard, ""},
+		{New("root"), ""},
+		{New("root:a:b"), ""},
+		{New(""), "must not be empty"},
+		{New("root::a"), "empty segment at index 5"},
+		{New("root:a:"), "empty segment at index 7"},
+		{New("root:a.b"), "illegal character '.' at index 6"},
+		{New("ROOT"), "illegal character 'R' at index 0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String(), func(t *testing.T) {
+			err := tt.name.Validate()
+			if tt.wantReason == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			var invalid *InvalidNameError
+			if !errors.As(err, &invalid) {
+				t.Fatalf("expected *InvalidNameError, got %v", err)
+			}
+			if invalid.Value != tt.name.String() || invalid.Reason != tt.wantReason {
+				t.Errorf("incorrect error, expected value %q with reason %q, got %q with %q", tt.name, tt.wantReason, invalid.Value, invalid.Reason)
+			}
+		})
+	}
+}
+
+func TestName_ValidateDepth(t *testing.T) {
+	tests := []struct {
+		name    Name
+		max     int
+		wantErr string
+	}{
+		{New("root:a:b"), 0, ""},
+		{New("root:a:b"), -1, ""},
+		{New("root:a:b"), 4, ""},
+		{New("root:a:b"), 3, ""},
+		{New("root:a:b"), 2, `invalid logical cluster name "root:a:b": depth 3 exceeds maximum depth 2`},
+		{New("root:a:b:c:d:e:f:g:h"), 8, `invalid logical cluster name "root:a:b:c:d:e:f:g:h": depth 9 exceeds maximum depth 8`},
+		{Wildcard, 1, ""},
+		{New("root:"), 8, `invalid logical cluster name "root:": empty segment at index 5`},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/%d", tt.name, tt.max), func(t *testing.T) {
+			err := tt.name.ValidateDepth(tt.max)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("incorrect error, expected %s, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestName_ValidateMaxLength(t *testing.T) {
+	long := New(strings.TrimSuffix(strings.Repeat("abcdefgh:", 1200), ":"))
+	tests := []struct {
+		name    Name
+		max     int
+		wantErr string
+	}{
+		{New("root:a:b"), 0, ""},
+		{New("root:a:b"), -1, ""},
+		{New("root:a:b"), 9, ""},
+		{New("root:a:b"), 8, ""},
+		{New("root:a:b"), 7, `invalid logical cluster name "root:a:b": length 8 exceeds maximum length 7`},
+		{Wildcard, 1, ""},
+		{New("root:"), 8, `invalid logical cluster name "root:": empty segment at index 5`},
+		{long, DefaultMaxLength, fmt.Sprintf("invalid logical cluster name %q: length 10799 exceeds maximum length 1024", long)},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%.20s/%d", tt.name, tt.max), func(t *testing.T) {
+			err := tt.name.ValidateMaxLength(tt.max)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("incorrect error, expected %s, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestName_IsSystem(t *testing.T) {
+	tests := []struct {
+		name Name
+		want bool
+	}{
+		{New(""), false},
+		{Wildcard, false},
+		{New("system"), true},
+		{New("system:foo"), true},
+		{New("system:foo:bar"), true},
+		{New("systematic"), false},
+		{New("systematic:foo"), false},
+		{New("System"), false},
+		{New("root:system"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String(), func(t *testing.T) {
+			if got := tt.name.IsSystem(); got != tt.want {
+				t.Errorf("%q.IsSystem() = %v, want %v", tt.name, got, tt.want)
+			}
+			if got := tt.name.HasRootSegment("system"); got != tt.want {
+				t.Errorf("%q.HasRootSegment(%q) = %v, want %v", tt.name, "system", got, tt.want)
+			}
+		})
+	}
+
+	if !New("root:a").HasRootSegment("root") {
+		t.Errorf("expected %q to have root segment %q", "root:a", "root")
+	}
+	if New("root:a").HasRootSegment("") {
+		t.Errorf("expected %q not to have empty root segment", "root:a")
+	}
+}
+
+type testObject struct {
+	annotations map[string]string
+	labels      map[string]string
+}
+
+func (o *testObject) GetLabels() map[string]string {
+	return o.labels
+}
+
+func (o *testObject) GetAnnotations() map[string]string {
+	return o.annotations
+}
+
+func (o *testObject) SetAnnotations(annotations map[string]string) {
+	o.annotations = annotations
+}
+
+func TestFrom(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		key         string
+		want        Name
+	}{
+		{"default key", map[string]string{AnnotationKey: "root:a"}, AnnotationKey, New("root:a")},
+		{"custom key", map[string]string{AnnotationKey: "root:a", "example.com/cluster": "root:b"}, "example.com/cluster", New("root:b")},
+		{"missing key", map[string]string{AnnotationKey: "root:a"}, "example.com/cluster", New("")},
+		{"multiple segments", map[string]string{AnnotationKey: "root:a:b:c"}, AnnotationKey, New("root:a:b:c")},
+		{"not validated", map[string]string{AnnotationKey: "Root::a"}, AnnotationKey, New("Root::a")},
+		{"nil annotations", nil, AnnotationKey, New("")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &testObject{annotations: tt.annotations}
+			if got := FromWithKey(obj, tt.key); got != tt.want {
+				t.Errorf("FromWithKey(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+			if tt.key == AnnotationKey {
+				if got := From(obj); got != tt.want {
+					t.Errorf("From() = %q, want %q", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSetOn(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        map[string]string
+	}{
+		{"nil annotations", nil, map[string]string{AnnotationKey: "root:a"}},
+		{"existing annotations", map[string]string{"foo": "bar"}, map[string]string{"foo": "bar", AnnotationKey: "root:a"}},
+		{"overwrite", map[string]string{"foo": "bar", AnnotationKey: "root:b"}, map[string]string{"foo": "bar", AnnotationKey: "root:a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &testObject{annotations: tt.annotations}
+			SetOn(obj, New("root:a"))
+			if !reflect.DeepEqual(obj.annotations, tt.want) {
+				t.Errorf("incorrect annotations, expected %v, got %v", tt.want, obj.annotations)
+			}
+			if got := From(obj); got != New("root:a") {
+				t.Errorf("From() = %q, want %q", got, "root:a")
+			}
+		})
+	}
+}
+
+func TestName_Matches(t *testing.T) {
+	tests := []struct {
+		name, pattern Name
+		want          bool
+	}{
+		{New(""), New(""), true},
+		{New("root"), New(""), false},
+		{New(""), New("*"), false},
+		{New("root"), New("root"), true},
+		{New("root"), New("*"), true},
+		{New("root:a"), New("*"), false},
+		{New("root:a:invoices"), New("root:*:invoices"), true},
+		{New("root:b:invoices"), New("root:*:invoices"), true},
+		{New("root:a:payments"), New("root:*:invoices"), false},
+		{New("root:a:b:invoices"), New("root:*:invoices"), false},
+		{New("root:invoices"), New("root:*:invoices"), false},
+		{New("root::invoices"), New("root:*:invoices"), false},
+		{New("root:a:invoices"), New("*:*:*"), true},
+		{New("root:a"), New("root:a"), true},
+		{New("root:a"), New("root:ab"), false},
+		{New("root:ab"), New("root:a"), false},
+		{Wildcard, Wildcard, true},
+
+		// ** at the end
+		{New("root"), New("root:**"), true},
+		{New("root:a"), New("root:**"), true},
+		{New("root:a:b"), New("root:**"), true},
+		{New("rootx:a"), New("root:**"), false},
+		{New("other"), New("root:**"), false},
+		{New("root::a"), New("root:**"), false},
+
+		// ** at the start
+		{New("invoices"), New("**:invoices"), true},
+		{New("root:invoices"), New("**:invoices"), true},
+		{New("root:a:b:invoices"), New("**:invoices"), true},
+		{New("root:a:b:payments"), New("**:invoices"), false},
+		{New("root:invoices:a"), New("**:invoices"), false},
+
+		// ** in the middle
+		{New("root:invoices"), New("root:**:invoices"), true},
+		{New("root:a:invoices"), New("root:**:invoices"), true},
+		{New("root:a:b:c:invoices"), New("root:**:invoices"), true},
+		{New("root:a:invoices:b"), New("root:**:invoices"), false},
+		{New("other:a:invoices"), New("root:**:invoices"), false},
+		{New("root:invoices:a:invoices"), New("root:**:invoices"), true},
+
+		// combinations
+		{New(""), New("**"), true},
+		{New("root:a:b"), New("**"), true},
+		{New("root:a:b"), New("**:**"), true},
+		{New("root:a:b"), New("**:*:b"), true},
+		{New("b"), New("**:*:b"), false},
+		{New("root:a:b:c"), New("*:**:c"), true},
+		{New("c"), New("*:**:c"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String()+"/"+tt.pattern.String(), func(t *testing.T) {
+			if got := tt.name.Matches(tt.pattern); got != tt.want {
+				t.Errorf("%q.Matches(%q) = %v, want %v", tt.name, tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestName_HasSuffix(t *testing.T) {
+	tests := []struct {
+		name, suffix Name
+		want         bool
+	}{
+		{New(""), New(""), true},
+		{New("root"), New(""), true},
+		{New(""), New("root"), false},
+		{New("root"), New("root"), true},
+		{New("root:a:invoices"), New("invoices"), true},
+		{New("root:a:invoices"), New("a:invoices"), true},
+		{New("root:a:invoices"), New("root:a:invoices"), true},
+		{New("root:a:xinvoices"), New("invoices"), false},
+		{New("root:ba:invoices"), New("a:invoices"), false},
+		{New("invoices"), New("a:invoices"), false},
+		{New("root:a:"), New(":"), false},
+		{New("root::"), New(":"), true},
+		{Wildcard, Wildcard, true},
+		{New("root:*"), Wildcard, true},
+		{New("root"), Wildcard, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String()+"/"+tt.suffix.String(), func(t *testing.T) {
+			if got := tt.name.HasSuffix(tt.suffix); got != tt.want {
+				t.Errorf("%q.HasSuffix(%q) = %v, want %v", tt.name, tt.suffix, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestName_TrimSuffix(t *testing.T) {
+	tests := []struct {
+		name, suffix Name
+		want         Name
+	}{
+		{New(""), New(""), New("")},
+		{New("root:a:invoices"), New(""), New("root:a:invoices")},
+		{New("root:a:invoices"), New("invoices"), New("root:a")},
+		{New("root:a:invoices"), New("a:invoices"), New("root")},
+		{New("root:a:invoices"), New("root:a:invoices"), New("")},
+		{New("root:a:xinvoices"), New("invoices"), New("root:a:xinvoices")},
+		{New("root:a:invoices"), New("payments"), New("root:a:invoices")},
+		{New("invoices"), New("a:invoices"), New("invoices")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String()+"/"+tt.suffix.String(), func(t *testing.T) {
+			if got := tt.name.TrimSuffix(tt.suffix); got != tt.want {
+				t.Errorf("%q.TrimSuffix(%q) = %q, want %q", tt.name, tt.suffix, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestName_Normalize(t *testing.T) {
+	tests := []struct {
+		name, want Name
+	}{
+		{New(""), New("")},
+		{New(":"), New("")},
+		{New(":::"), New("")},
+		{New("foo"), New("foo")},
+		{New("foo:bar:baz"), New("foo:bar:baz")},
+		{New("foo::baz"), New("foo:baz")},
+		{New("foo:::baz"), New("foo:baz")},
+		{New(":foo"), New("foo")},
+		{New("foo:"), New("foo")},
+		{New("::foo::bar::"), New("foo:bar")},
+		{New("Foo::Bar_"), New("Foo:Bar_")},
+		{Wildcard, Wildcard},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String(), func(t *testing.T) {
+			if got := tt.name.Normalize(); got != tt.want {
+				t.Errorf("%q.Normalize() = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestName_Format(t *testing.T) {
+	tests := []struct {
+		format string
+		value  interface{}
+		want   string
+	}{
+		{"%s", New("root:a"), `root:a`},
+		{"%v", New("root:a"), `root:a`},
+		{"%q", New("root:a"), `"root:a"`},
+		{"%#v", New("root:a"), `logicalcluster.New("root:a")`},
+		{"%#v", New(""), `logicalcluster.New("")`},
+		{"%#v", Wildcard, `logicalcluster.New("*")`},
+		{"%#v", []Name{New("root"), New("root:a")}, `[]logicalcluster.Name{logicalcluster.New("root"), logicalcluster.New("root:a")}`},
+		{"%s", &Name{value: "root:a"}, `root:a`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			if got := fmt.Sprintf(tt.format, tt.value); got != tt.want {
+				t.Errorf("Sprintf(%q) = %s, want %s", tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestName_LogField(t *testing.T) {
+	// mimics zap.String
+	field := func(key, value string) string { return key + "=" + value }
+
+	if got, want := field(New("root:a").LogField()), "cluster=root:a"; got != want {
+		t.Errorf("LogField() = %s, want %s", got, want)
+	}
+	if got, want := field(New("").LogField()), "cluster="; got != want {
+		t.Errorf("LogField() = %s, want %s", got, want)
+	}
+}
+
+var fuzzSeeds = []string{
+	"", "*", "**", ":", "root", "root:a", "root:a-b:0c", "root:", ":root", "root::a",
+	"root:-a", "root:a-", "root:A", "root:föö", "root/a", "a-", "-", "::a::b::",
+	strings.Repeat("a", 63), strings.Repeat("a", 64), "root:" + strings.Repeat("a", 64) + ":b",
+}
+
+func FuzzIsValid(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, value string) {
+		if got, want := isValidName(value), lclusterRegExp.MatchString(value); got != want {
+			t.Errorf("isValidName(%q) = %v, but regular expression matches %v", value, got, want)
+		}
+		if got, want := invalidReason(value) == "", isValidName(value); got != want {
+			t.Errorf("invalidReason(%q) = %q, but isValidName() = %v", value, invalidReason(value), want)
+		}
+		if _, err := ParseName(value); (err == nil) != New(value).IsValid() {
+			t.Errorf("ParseName(%q) = %v, but IsValid() = %v", value, err, New(value).IsValid())
+		}
+	})
+}
+
+func FuzzParseName(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, value string) {
+		normalized := New(value).Normalize()
+		if normalized.Normalize() != normalized {
+			t.Errorf("Normalize() of %q is not idempotent: %q", value, normalized)
+		}
+		if strings.Contains(":"+normalized.String()+":", "::") && !normalized.Empty() {
+			t.Errorf("Normalize() of %q has empty segments: %q", value, normalized)
+		}
+		if normalized.Empty() && New(value).IsValid() {
+			t.Errorf("Normalize() of valid %q is empty", value)
+		}
+
+		n, err := ParseName(value)
+		if err != nil {
+			return
+		}
+		if normalized != n {
+			t.Errorf("Normalize() of valid %q = %q", value, normalized)
+		}
+		text, err := n.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var final Name
+		if err := final.UnmarshalText(text); err != nil {
+			t.Fatalf("UnmarshalText(%q) failed: %v", text, err)
+		}
+		if final != n {
+			t.Errorf("text round-trip of %q resulted in %q", n, final)
+		}
+	})
+}
+
+func TestIsValidAllocations(t *testing.T) {
+	for _, n := range []Name{Wildcard, New("root:accounting:us-west"), New("root:Invalid"), New("")} {
+		if allocs := testing.AllocsPerRun(100, func() { n.IsValid() }); allocs != 0 {
+			t.Errorf("%q.IsValid() allocates %v times, want 0", n, allocs)
+		}
+	}
+}
+
+func BenchmarkIsValid(b *testing.B) {
+	values := []string{"root", "root:accounting:us-west:invoices", "root:test-8827a131-f796-4473-8904-a0fa527696eb", "root:Invalid", "root::a"}
+
+	b.Run("scanner", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, v := range values {
+				isValidName(v)
+			}
+		}
+	})
+	b.Run("regexp", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, v := range values {
+				lclusterRegExp.MatchString(v)
+			}
+		}
+	})
+}
+
+func TestSplitAllocations(t *testing.T) {
+	n := New("root:accounting:us-west")
+	if allocs := testing.AllocsPerRun(100, func() { n.Split() }); allocs != 0 {
+		t.Errorf("Split() allocates %v times, want 0", allocs)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { n.Base() }); allocs != 0 {
+		t.Errorf("Base() allocates %v times, want 0", allocs)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { n.Parent() }); allocs != 0 {
+		t.Errorf("Parent() allocates %v times, want 0", allocs)
+	}
+}
+
+func TestJoinAllocations(t *testing.T) {
+	tests := []struct {
+		name  Name
+		names []string
+		want  float64
+	}{
+		{New("root:a"), nil, 0},
+		{New(""), []string{"root"}, 0},
+		{New("root"), []string{"a"}, 1},
+		{New("root"), []string{"accounting", "us-west", "team-a", "invoices"}, 1},
+		{New(""), []string{"root", "accounting", "us-west", "team-a", "invoices"}, 1},
+	}
+	for _, tt := range tests {
+		if allocs := testing.AllocsPerRun(100, func() { tt.name.Join(tt.names...) }); allocs != tt.want {
+			t.Errorf("%q.Join(%q) allocates %v times, want %v", tt.name, tt.names, allocs, tt.want)
+		}
+	}
+}
+
+func BenchmarkSplit(b *testing.B) {
+	n := New("root:accounting:us-west")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		n.Split()
+	}
+}
+
+func BenchmarkBase(b *testing.B) {
+	n := New("root:accounting:us-west")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		n.Base()
+	}
+}
+
+func TestName_CountSegment(t *testing.T) {
+	tests := []struct {
+		name    Name
+		segment string
+		want    int
+	}{
+		{New(""), "", 0},
+		{New(""), "a", 0},
+		{New("a"), "a", 1},
+		{New("a:b:a"), "a", 2},
+		{New("a:b:a"), "b", 1},
+		{New("a:a:a"), "a", 3},
+		{New("ab:ba"), "a", 0},
+		{New("root:accounting"), "account", 0},
+		{New("foo::bar"), "", 1},
+		{Wildcard, "*", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String()+"/"+tt.segment, func(t *testing.T) {
+			if got := tt.name.CountSegment(tt.segment); got != tt.want {
+				t.Errorf("%q.CountSegment(%q) = %d, want %d", tt.name, tt.segment, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestName_ContainsSegment(t *testing.T) {
+	tests := []struct {
+		name    Name
+		segment string
+		want    bool
+	}{
+		{New(""), "", false},
+		{New("root:account:a"), "root", true},
+		{New("root:account:a"), "account", true},
+		{New("root:account:a"), "a", true},
+		{New("root:account:a"), "b", false},
+		{New("root:accounting"), "account", false},
+		{New("root:myaccount"), "account", false},
+		{New("root:account"), "root:account", false},
+		{New("foo::bar"), "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String()+"/"+tt.segment, func(t *testing.T) {
+			if got := tt.name.ContainsSegment(tt.segment); got != tt.want {
+				t.Errorf("%q.ContainsSegment(%q) = %v, want %v", tt.name, tt.segment, got, tt.want)
+			}
+			if got := tt.name.CountSegment(tt.segment) > 0; got != tt.want {
+				t.Errorf("%q.CountSegment(%q) > 0 = %v, want %v", tt.name, tt.segment, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestName_SegmentAt(t *testing.T) {
+	tests := []struct {
+		name   Name
+		i      int
+		want   string
+		wantOk bool
+	}{
+		{New(""), 0, "", false},
+		{New(""), -1, "", false},
+		{New("root"), 0, "root", true},
+		{New("root"), 1, "", false},
+		{New("root"), -1, "root", true},
+		{New("root"), -2, "", false},
+		{New("root:tenant:app"), 0, "root", true},
+		{New("root:tenant:app"), 1, "tenant", true},
+		{New("root:tenant:app"), 2, "app", true},
+		{New("root:tenant:app"), 3, "", false},
+		{New("root:tenant:app"), -1, "app", true},
+		{New("root:tenant:app"), -3, "root", true},
+		{New("root:tenant:app"), -4, "", false},
+		{New("foo::bar"), 1, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/%d", tt.name, tt.i), func(t *testing.T) {
+			got, gotOk := tt.name.SegmentAt(tt.i)
+			if got != tt.want || gotOk != tt.wantOk {
+				t.Errorf("%q.SegmentAt(%d) = (%q, %v), want (%q, %v)", tt.name, tt.i, got, gotOk, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestName_Reverse(t *testing.T) {
+	tests := []struct {
+		name, want Name
+	}{
+		{New(""), New("")},
+		{New("root"), New("root")},
+		{Wildcard, Wildcard},
+		{New("root:a"), New("a:root")},
+		{New("root:a:b"), New("b:a:root")},
+		{New("root:a:"), New(":a:root")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String(), func(t *testing.T) {
+			if got := tt.name.Reverse(); got != tt.want {
+				t.Errorf("%q.Reverse() = %q, want %q", tt.name, got, tt.want)
+			}
+			if got := tt.name.Reverse().Reverse(); got != tt.name {
+				t.Errorf("%q.Reverse().Reverse() = %q", tt.name, got)
+			}
+		})
+	}
+}
+
+func TestParseAny(t *testing.T) {
+	tests := []struct {
+		s      string
+		want   Name
+		wantOk bool
+	}{
+		{"root:a", New("root:a"), true},
+		{"root:a/", New("root:a"), true},
+		{"/clusters/root:a", New("root:a"), true},
+		{"/clusters/root:a/", New("root:a"), true},
+		{"*", Wildcard, true},
+		{"/clusters/*", Wildcard, true},
+		{"", New(""), false},
+		{"/clusters/", New(""), false},
+		{"/clusters/root:a/api", New(""), false},
+		{"/clusters/root:A", New(""), false},
+		{"root::a", New(""), false},
+		{"/root:a", New(""), false},
+		{"root:a//", New(""), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, gotOk := ParseAny(tt.s)
+			if got != tt.want || gotOk != tt.wantOk {
+				t.Errorf("ParseAny(%q) = (%q, %v), want (%q, %v)", tt.s, got, gotOk, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestName_IsWildcard(t *testing.T) {
+	tests := []struct {
+		name Name
+		want bool
+	}{
+		{Wildcard, true},
+		{New("*"), true},
+		{New(""), false},
+		{New("root"), false},
+		{New("root:*"), false},
+		{New("**"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String(), func(t *testing.T) {
+			if got := tt.name.IsWildcard(); got != tt.want {
+				t.Errorf("%q.IsWildcard() = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsValidWithReassignedWildcard(t *testing.T) {
+	defer func(original Name) { Wildcard = original }(Wildcard)
+	Wildcard = New("root")
+
+	if !New("*").IsValid() {
+		t.Errorf("expected %q to be valid", "*")
+	}
+	if !New("*").IsWildcard() {
+		t.Errorf("expected %q to be the wildcard", "*")
+	}
+	if New("root").IsWildcard() {
+		t.Errorf("expected %q not to be the wildcard", "root")
+	}
+	if _, err := ParseName("*"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestName_Immutable(t *testing.T) {
+	n := New("root:a:b")
+	clone := n.Clone()
+	if clone != n {
+		t.Errorf("Clone() = %q, want %q", clone, n)
+	}
+
+	segments := n.Segments()
+	segments[0] = "other"
+	_ = append(segments[:1], "x")
+	ancestors := n.Ancestors()
+	ancestors[0] = New("other")
+	s := NewNameSet(n)
+	list := s.SortedList()
+	list[0] = New("other")
+
+	if n != New("root:a:b") || clone != New("root:a:b") {
+		t.Errorf("name was mutated to %q, clone to %q", n, clone)
+	}
+	if got := n.Segments(); !reflect.DeepEqual(got, []string{"root", "a", "b"}) {
+		t.Errorf("Segments() = %q after mutating a previous result", got)
+	}
+	if got := n.Ancestors(); !reflect.DeepEqual(got, []Name{New("root:a"), New("root")}) {
+		t.Errorf("Ancestors() = %v after mutating a previous result", got)
+	}
+	if !s.Has(n) || s.Len() != 1 {
+		t.Errorf("set was mutated to %v", s)
+	}
+}
+
+func TestName_JoinSegment(t *testing.T) {
+	tests := []struct {
+		name    Name
+		child   string
+		want    Name
+		wantErr string
+	}{
+		{New(""), "root", New("root"), ""},
+		{New("root"), "a", New("root:a"), ""},
+		{New("root"), "a-b", New("root:a-b"), ""},
+		{New("root"), "a:b", New("root"), `logical cluster name component "a:b" must not contain ":"`},
+		{New("root"), ":", New("root"), `logical cluster name component ":" must not contain ":"`},
+		{New(""), "root:a", New(""), `logical cluster name component "root:a" must not contain ":"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String()+"/"+tt.child, func(t *testing.T) {
+			got, err := tt.name.JoinSegment(tt.child)
+			if got != tt.want {
+				t.Errorf("%q.JoinSegment(%q) = %q, want %q", tt.name, tt.child, got, tt.want)
+			}
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("incorrect error, expected %s, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestName_HasCycle(t *testing.T) {
+	tests := []struct {
+		name Name
+		want bool
+	}{
+		{New(""), false},
+		{New("root"), false},
+		{New("root:a:b"), false},
+		{New("root:a:root"), true},
+		{New("root:a:a"), true},
+		{New("a:b:c:b"), true},
+		{New("root:ab:a:b"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String(), func(t *testing.T) {
+			if got := tt.name.HasCycle(); got != tt.want {
+				t.Errorf("%q.HasCycle() = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestName_IsValidStrict(t *testing.T) {
+	tests := []struct {
+		name Name
+		want bool
+	}{
+		{New("root"), true},
+		{New("root:a"), true},
+		{New(""), false},
+		{Wildcard, false},
+		{New("*"), false},
+		{New("."), false},
+		{New(".."), false},
+		{New("root:."), false},
+		{New("root:.."), false},
+		{New("root:*"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String(), func(t *testing.T) {
+			if got := tt.name.IsValidStrict(); got != tt.want {
+				t.Errorf("%q.IsValidStrict() = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestName_TrimToDepth(t *testing.T) {
+	tests := []struct {
+		name  Name
+		depth int
+		want  Name
+	}{
+		{New("root:a:b:c"), 2, New("root:a")},
+		{New("root:a:b:c"), 1, New("root")},
+		{New("root:a:b:c"), 3, New("root:a:b")},
+		{New("root:a:b:c"), 4, New("root:a:b:c")},
+		{New("root:a:b:c"), 10, New("root:a:b:c")},
+		{New("root:a:b:c"), 0, New("")},
+		{New("root:a:b:c"), -1, New("")},
+		{New(""), 2, New("")},
+		{New("root:"), 1, New("root")},
+		{Wildcard, 1, Wildcard},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/%d", tt.name, tt.depth), func(t *testing.T) {
+			if got := tt.name.TrimToDepth(tt.depth); got != tt.want {
+				t.Errorf("%q.TrimToDepth(%d) = %q, want %q", tt.name, tt.depth, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestName_Tail(t *testing.T) {
+	tests := []struct {
+		name   Name
+		want   Name
+		wantOk bool
+	}{
+		{New(""), New(""), false},
+		{New("root"), New(""), false},
+		{Wildcard, New(""), false},
+		{New("root:a"), New("a"), true},
+		{New("root:a:b"), New("a:b"), true},
+		{New("62208dab:a:b"), New("a:b"), true},
+		{New("*:a"), New("a"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String(), func(t *testing.T) {
+			got, gotOk := tt.name.Tail()
+			if got != tt.want || gotOk != tt.wantOk {
+				t.Errorf("%q.Tail() = (%q, %v), want (%q, %v)", tt.name, got, gotOk, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestName_Canonical(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{"foo", "foo:"},
+		{"foo", ":foo"},
+		{"foo:bar", "foo::bar"},
+		{"foo:bar", ":foo:bar:"},
+		{"", ":"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			m := map[Name]bool{New(tt.a).Canonical(): true}
+			if !m[NewCanonical(tt.b)] {
+				t.Errorf("expected %q and %q to have the same canonical form, got %q and %q", tt.a, tt.b, New(tt.a).Canonical(), NewCanonical(tt.b))
+			}
+		})
+	}
+
+	if NewCanonical("Foo") == NewCanonical("foo") {
+		t.Errorf("expected canonical form to be case-sensitive")
+	}
+}
+
+func TestMustNew(t *testing.T) {
+	tests := []struct {
+		value     string
+		wantPanic string
+	}{
+		{"root:a", ""},
+		{"*", ""},
+		{"", `invalid logical cluster name "": must not be empty`},
+		{"root:A", `invalid logical cluster name "root:A": illegal character 'A' at index 5`},
+	}
+	for _, tt := range tests {
+		for name, fn := range map[string]func(string) Name{"MustNew": MustNew, "MustParseName": MustParseName} {
+			t.Run(name+"/"+tt.value, func(t *testing.T) {
+				defer func() {
+					r := recover()
+					if tt.wantPanic == "" && r != nil {
+						t.Errorf("unexpected panic: %v", r)
+					}
+					if tt.wantPanic != "" && fmt.Sprint(r) != tt.wantPanic {
+						t.Errorf("incorrect panic, expected %s, got %v", tt.wantPanic, r)
+					}
+				}()
+				if got := fn(tt.value); got != New(tt.value) {
+					t.Errorf("%s(%q) = %q", name, tt.value, got)
+				}
+			})
+		}
+	}
+}
+
+func TestName_LeafName(t *testing.T) {
+	tests := []struct {
+		name   Name
+		want   Name
+		wantOk bool
+	}{
+		{New(""), New(""), false},
+		{New("root"), New("root"), true},
+		{New("root:a:b"), New("b"), true},
+		{New("root:a:B"), New(""), false},
+		{New("root:a:"), New(""), false},
+		{New("Root:a:b"), New("b"), true},
+		{Wildcard, New(""), false},
+		{New("root:*"), New(""), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String(), func(t *testing.T) {
+			got, gotOk := tt.name.LeafName()
+			if got != tt.want || gotOk != tt.wantOk {
+				t.Errorf("%q.LeafName() = (%q, %v), want (%q, %v)", tt.name, got, gotOk, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestValidateNames(t *testing.T) {
+	if errs := ValidateNames(nil); errs != nil {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	if errs := ValidateNames([]Name{New("root"), Wildcard, New("root:a")}); errs != nil {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+
+	errs := ValidateNames([]Name{New("root"), New("root:"), New("root:a"), New(""), New("Root")})
+	want := []string{
+		`[1]: invalid logical cluster name "root:": empty segment at index 5`,
+		`[3]: invalid logical cluster name "": must not be empty`,
+		`[4]: invalid logical cluster name "Root": illegal character 'R' at index 0`,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errs)
+	}
+	for i, err := range errs {
+		if err.Error() != want[i] {
+			t.Errorf("incorrect error, expected %s, got %s", want[i], err)
+		}
+		var invalid *InvalidNameError
+		if !errors.As(err, &invalid) {
+			t.Errorf("expected error to wrap *InvalidNameError, got %v", err)
+		}
+	}
+}
+
+func TestName_EqualFold(t *testing.T) {
+	tests := []struct {
+		a, b Name
+		want bool
+	}{
+		{New(""), New(""), true},
+		{New("root:accounting"), New("root:accounting"), true},
+		{New("Root:Accounting"), New("root:accounting"), true},
+		{New("ROOT:ACCOUNTING"), New("root:Accounting"), true},
+		{New("root:accounting"), New("root:accountin"), false},
+		{New("root:accounting"), New("root:sales"), false},
+		{New("root:a"), New("root:a:"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a.String()+"/"+tt.b.String(), func(t *testing.T) {
+			if got := tt.a.EqualFold(tt.b); got != tt.want {
+				t.Errorf("%q.EqualFold(%q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestName_EqualIgnoringRoot(t *testing.T) {
+	tests := []struct {
+		a, b Name
+		want bool
+	}{
+		{New(""), New(""), true},
+		{New("root:a:b"), New("root:a:b"), true},
+		{New("root:a:b"), New("62208dab:a:b"), true},
+		{New("root"), New("62208dab"), true},
+		{New("root:a:b"), New("62208dab:a:c"), false},
+		{New("root:a:b"), New("62208dab:a"), false},
+		{New("root:a"), New("root:a:b"), false},
+		{New("root"), New("root:"), false},
+		{New("root"), New(""), false},
+		{New(":a"), New("a"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a.String()+"/"+tt.b.String(), func(t *testing.T) {
+			if got := tt.a.EqualIgnoringRoot(tt.b); got != tt.want {
+				t.Errorf("%q.EqualIgnoringRoot(%q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+			if got := tt.b.EqualIgnoringRoot(tt.a); got != tt.want {
+				t.Errorf("%q.EqualIgnoringRoot(%q) = %v, want %v", tt.b, tt.a, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestName_ToLower(t *testing.T) {
+	tests := []struct {
+		name, want Name
+	}{
+		{New(""), New("")},
+		{New("root:accounting"), New("root:accounting")},
+		{New("Root:Accounting"), New("root:accounting")},
+		{New("ROOT:A-B:0C"), New("root:a-b:0c")},
+		{New("Root:ÄÖÜ"), New("root:ÄÖÜ")},
+		{New("Root_A"), New("root_a")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String(), func(t *testing.T) {
+			if got := tt.name.ToLower(); got != tt.want {
+				t.Errorf("%q.ToLower() = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestName_JoinNormalized(t *testing.T) {
+	tests := []struct {
+		name    Name
+		child   string
+		want    Name
+		wantErr string
+	}{
+		{New("root"), "  My-Team  ", New("root:my-team"), ""},
+		{New("root"), "team", New("root:team"), ""},
+		{New("root"), "\tTEAM\n", New("root:team"), ""},
+		{New(""), " Root ", New("root"), ""},
+		{New("root"), "my team", New("root"), `invalid logical cluster name "my team": illegal character ' ' at index 2`},
+		{New("root"), "my_team", New("root"), `invalid logical cluster name "my_team": illegal character '_' at index 2`},
+		{New("root"), "-team", New("root"), `invalid logical cluster name "-team": segment "-team" must not start with a hyphen`},
+		{New("root"), "   ", New("root"), `invalid logical cluster name "": must not be empty`},
+		{New("root"), "*", New("root"), `invalid logical cluster name "*": illegal character '*' at index 0`},
+		{New("root"), "A:B", New("root"), `logical cluster name component "a:b" must not contain ":"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String()+"/"+tt.child, func(t *testing.T) {
+			got, err := tt.name.JoinNormalized(tt.child)
+			if got != tt.want {
+				t.Errorf("%q.JoinNormalized(%q) = %q, want %q", tt.name, tt.child, got, tt.want)
+			}
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("incorrect error, expected %s, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestFromObject(t *testing.T) {
+	const labelKey = "example.com/cluster"
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		labels      map[string]string
+		want        Name
+		wantLabel   Name
+	}{
+		{"annotation only", map[string]string{AnnotationKey: "root:a"}, nil, New("root:a"), New("")},
+		{"label only", nil, map[string]string{labelKey: "root:b"}, New("root:b"), New("root:b")},
+		{"both", map[string]string{AnnotationKey: "root:a"}, map[string]string{labelKey: "root:b"}, New("root:a"), New("root:b")},
+		{"other label", nil, map[string]string{"other": "root:b"}, New(""), New("")},
+		{"neither", nil, nil, New(""), New("")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &testObject{annotations: tt.annotations, labels: tt.labels}
+			if got := FromObject(obj, labelKey); got != tt.want {
+				t.Errorf("FromObject() = %q, want %q", got, tt.want)
+			}
+			if got := FromLabels(obj, labelKey); got != tt.wantLabel {
+				t.Errorf("FromLabels() = %q, want %q", got, tt.wantLabel)
+			}
+		})
+	}
+}
+
+func TestClearOn(t *testing.T) {
+	var _ MutableObject = &testObject{}
+
+	obj := &testObject{annotations: map[string]string{"foo": "bar"}}
+	SetOn(obj, New("root:a"))
+	if got := From(obj); got != New("root:a") {
+		t.Errorf("From() after SetOn() = %q, want %q", got, "root:a")
+	}
+
+	ClearOn(obj)
+	if got := From(obj); got != New("") {
+		t.Errorf("From() after ClearOn() = %q, want empty", got)
+	}
+	if expected := map[string]string{"foo": "bar"}; !reflect.DeepEqual(obj.annotations, expected) {
+		t.Errorf("incorrect annotations, expected %v, got %v", expected, obj.annotations)
+	}
+
+	empty := &testObject{}
+	ClearOn(empty)
+	if empty.annotations != nil {
+		t.Errorf("expected annotations to stay nil, got %v", empty.annotations)
+	}
+}
+
+func TestName_Prefixes(t *testing.T) {
+	tests := []struct {
+		name Name
+		want []Name
+	}{
+		{New(""), []Name{}},
+		{Wildcard, []Name{Wildcard}},
+		{New("root"), []Name{New("root")}},
+		{New("root:a"), []Name{New("root"), New("root:a")}},
+		{New("root:a:b"), []Name{New("root"), New("root:a"), New("root:a:b")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String(), func(t *testing.T) {
+			if got := tt.name.Prefixes(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q.Prefixes() = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestName_Breadcrumbs(t *testing.T) {
+	tests := []struct {
+		name Name
+		want []Breadcrumb
+	}{
+		{New(""), []Breadcrumb{}},
+		{Wildcard, []Breadcrumb{{"*", Wildcard}}},
+		{New("root"), []Breadcrumb{{"root", New("root")}}},
+		{New("root:a:b"), []Breadcrumb{{"root", New("root")}, {"a", New("root:a")}, {"b", New("root:a:b")}}},
+		{New("62208dab:a"), []Breadcrumb{{"62208dab", New("62208dab")}, {"a", New("62208dab:a")}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String(), func(t *testing.T) {
+			got := tt.name.Breadcrumbs()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q.Breadcrumbs() = %v, want %v", tt.name, got, tt.want)
+			}
+			for i, crumb := range got {
+				if segment := tt.name.Segments()[i]; crumb.Segment != segment {
+					t.Errorf("%q.Breadcrumbs()[%d].Segment = %q, but segment is %q", tt.name, i, crumb.Segment, segment)
+				}
+			}
+		})
+	}
+}
+
+func TestName_RequestPath(t *testing.T) {
+	tests := []struct {
+		name Name
+		want string
+	}{
+		{New(""), "/clusters"},
+		{New("root"), "/clusters/root"},
+		{New("root:org:ws"), "/clusters/root:org:ws"},
+		{Wildcard, "/clusters/*"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String(), func(t *testing.T) {
+			if got := tt.name.RequestPath(); got != tt.want {
+				t.Errorf("%q.RequestPath() = %q, want %q", tt.name, got, tt.want)
+			}
+			if got := tt.name.RequestPath(); got != tt.name.Path() {
+				t.Errorf("%q.RequestPath() = %q, but Path() = %q", tt.name, got, tt.name.Path())
+			}
+		})
+	}
+}
+
+func TestName_EscapedRequestPath(t *testing.T) {
+	tests := []struct {
+		name Name
+		want string
+	}{
+		{New("root"), "/clusters/root"},
+		{New("root:a-b:0c"), "/clusters/root:a-b:0c"},
+		{Wildcard, "/clusters/*"},
+		{New("root/a"), "/clusters/root%2Fa"},
+		{New("root a"), "/clusters/root%20a"},
+		{New("root?a"), "/clusters/root%3Fa"},
+		{New("root%2Aa"), "/clusters/root%252Aa"},
+		{New("root:*"), "/clusters/root:*"},
+		{New("röot"), "/clusters/r%C3%B6ot"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String(), func(t *testing.T) {
+			got := tt.name.EscapedRequestPath()
+			if got != tt.want {
+				t.Errorf("%q.EscapedRequestPath() = %q, want %q", tt.name, got, tt.want)
+			}
+			if tt.name.IsValid() && got != tt.name.Path() {
+				t.Errorf("%q.EscapedRequestPath() = %q, but Path() = %q", tt.name, got, tt.name.Path())
+			}
+			if unescaped, err := url.PathUnescape(strings.TrimPrefix(got, "/clusters/")); err != nil || unescaped != tt.name.String() {
+				t.Errorf("url.PathUnescape(%q) = (%q, %v), want %q", got, unescaped, err, tt.name)
+			}
+			if !tt.name.IsWildcard() && !strings.Contains(tt.name.String(), "*") && got != "/clusters/"+url.PathEscape(tt.name.String()) {
+				t.Errorf("%q.EscapedRequestPath() = %q, but url.PathEscape() gives %q", tt.name, got, url.PathEscape(tt.name.String()))
+			}
+		})
+	}
+}
+
+func TestIsHashSegment(t *testing.T) {
+	tests := []struct {
+		s    string
+		want bool
+	}{
+		{"62208dab", true},
+		{"c8a942c5", true},
+		{"00000000", true},
+		{"", false},
+		{"root", false},
+		{"62208da", false},
+		{"62208dab0", false},
+		{"62208DAB", false},
+		{"62208dag", false},
+		{"6220-dab", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			if got := IsHashSegment(tt.s); got != tt.want {
+				t.Errorf("IsHashSegment(%q) = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestName_RootIsHash(t *testing.T) {
+	tests := []struct {
+		name Name
+		want bool
+	}{
+		{New(""), false},
+		{New("62208dab"), true},
+		{New("62208dab:a:b"), true},
+		{New("root:a:b"), false},
+		{New("root:62208dab"), false},
+		{Wildcard, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String(), func(t *testing.T) {
+			if got := tt.name.RootIsHash(); got != tt.want {
+				t.Errorf("%q.RootIsHash() = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestName_Annotation(t *testing.T) {
+	for _, n := range []Name{New(""), New("root"), New("root:a:b")} {
+		key, value := n.Annotation()
+		if key != AnnotationKey {
+			t.Errorf("%q.Annotation() key = %q, want %q", n, key, AnnotationKey)
+		}
+		if value != n.String() {
+			t.Errorf("%q.Annotation() value = %q, want %q", n, value, n.String())
+		}
+
+		obj := &testObject{annotations: map[string]string{key: value}}
+		if got := From(obj); got != n {
+			t.Errorf("From() = %q, want %q", got, n)
+		}
+	}
+}
+
+func TestName_Diff(t *testing.T) {
+	tests := []struct {
+		got, want Name
+		diff      string
+	}{
+		{New(""), New(""), ""},
+		{New("root:a:b"), New("root:a:b"), ""},
+		{New("root:a:b"), New("root:a:c"), `diverges at segment 2: got "b", want "c"`},
+		{New("root:a:b"), New("other:a:b"), `diverges at segment 0: got "root", want "other"`},
+		{New("root:a"), New("root:a:b"), `diverges at segment 2: got end of name, want "b"`},
+		{New("root:a:b"), New("root"), `diverges at segment 1: got "a", want end of name`},
+		{New(""), New("root"), `diverges at segment 0: got end of name, want "root"`},
+		{New("root:"), New("root"), `diverges at segment 1: got "", want end of name`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.got.String()+"/"+tt.want.String(), func(t *testing.T) {
+			if got := tt.got.Diff(tt.want); got != tt.diff {
+				t.Errorf("%q.Diff(%q) = %s, want %s", tt.got, tt.want, got, tt.diff)
+			}
+		})
+	}
+}
+
+func TestName_IsChildOf(t *testing.T) {
+	tests := []struct {
+		name, parent Name
+		want         bool
+	}{
+		{New("root:a:b"), New("root:a"), true},
+		{New("root:a:b"), New("root"), false},
+		{New("root:a:b"), New("root:a:b"), false},
+		{New("root:a:b"), New("root:b"), false},
+		{New("root:ab:c"), New("root:a"), false},
+		{New("root:a"), New("root"), true},
+		{New("root"), New(""), false},
+		{New(""), New(""), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String()+"/"+tt.parent.String(), func(t *testing.T) {
+			if got := tt.name.IsChildOf(tt.parent); got != tt.want {
+				t.Errorf("%q.IsChildOf(%q) = %v, want %v", tt.name, tt.parent, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestName_ParentN(t *testing.T) {
+	tests := []struct {
+		name   Name
+		levels int
+		want   Name
+		wantOk bool
+	}{
+		{New("root:a:b:c"), 0, New("root:a:b:c"), true},
+		{New("root:a:b:c"), -1, New("root:a:b:c"), true},
+		{New("root:a:b:c"), 1, New("root:a:b"), true},
+		{New("root:a:b:c"), 2, New("root:a"), true},
+		{New("root:a:b:c"), 3, New("root"), true},
+		{New("root:a:b:c"), 4, New(""), false},
+		{New("root:a:b:c"), 10, New(""), false},
+		{New("root"), 1, New(""), false},
+		{New(""), 1, New(""), false},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/%d", tt.name, tt.levels), func(t *testing.T) {
+			got, gotOk := tt.name.ParentN(tt.levels)
+			if got != tt.want || gotOk != tt.wantOk {
+				t.Errorf("%q.ParentN(%d) = (%q, %v), want (%q, %v)", tt.name, tt.levels, got, gotOk, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestName_Append(t *testing.T) {
+	tests := []struct {
+		a, b, want Name
+	}{
+		{New(""), New(""), New("")},
+		{New("a"), New(""), New("a")},
+		{New(""), New("b"), New("b")},
+		{New("a"), New("b"), New("a:b")},
+		{New("root:a"), New("b:c"), New("root:a:b:c")},
+		{Wildcard, New("a"), New("*:a")},
+		{New("root"), Wildcard, New("root:*")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a.String()+"/"+tt.b.String(), func(t *testing.T) {
+			if got := tt.a.Append(tt.b); got != tt.want {
+				t.Errorf("%q.Append(%q) = %q, want %q", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWildcardRoundTrip(t *testing.T) {
+	raw, err := json.Marshal(Wildcard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(raw) != `"*"` {
+		t.Errorf("incorrect marshalled bytes, expected %s, got %s", `"*"`, raw)
+	}
+	var fromJSON Name
+	if err := json.Unmarshal(raw, &fromJSON); err != nil {
+		t.Fatal(err)
+	}
+
+	text, err := Wildcard.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(text) != "*" {
+		t.Errorf("incorrect marshalled text, expected %s, got %s", "*", text)
+	}
+	var fromText Name
+	if err := fromText.UnmarshalText(text); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, n := range []Name{fromJSON, fromText} {
+		if !n.IsWildcard() || !n.IsValid() || n != Wildcard {
+			t.Errorf("expected %q to be the valid wildcard", n)
+		}
+	}
+}
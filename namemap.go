@@ -0,0 +1,60 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalcluster
+
+import "encoding/json"
+
+// NameMap is a map keyed by logical cluster names which marshals to a JSON
+// object with the names as keys. Unmarshalling rejects keys which are not valid
+// logical cluster names, including the empty name.
+type NameMap[V any] map[Name]V
+
+// MarshalJSON implements json.Marshaler.
+func (m NameMap[V]) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return []byte("null"), nil
+	}
+	out := make(map[string]V, len(m))
+	for k, v := range m {
+		out[k.value] = v
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Like for builtin maps, entries
+// are added to an existing map, and null leaves the map unchanged.
+func (m *NameMap[V]) UnmarshalJSON(data []byte) error {
+	var in map[string]V
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	if in == nil {
+		return nil
+	}
+	for k := range in {
+		if _, err := ParseName(k); err != nil {
+			return err
+		}
+	}
+	if *m == nil {
+		*m = make(NameMap[V], len(in))
+	}
+	for k, v := range in {
+		(*m)[Name{k}] = v
+	}
+	return nil
+}
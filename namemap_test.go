@@ -0,0 +1,84 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalcluster
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestNameMap_JSON(t *testing.T) {
+	type state struct {
+		Phase string `json:"phase"`
+		Count int    `json:"count"`
+	}
+	m := NameMap[state]{
+		New("root"):         {Phase: "Ready", Count: 1},
+		New("root:org"):     {Phase: "Initializing", Count: 2},
+		New("root:org:ws"):  {Phase: "Ready", Count: 3},
+		New("system:admin"): {Phase: "Ready"},
+	}
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"root":{"phase":"Ready","count":1},"root:org":{"phase":"Initializing","count":2},"root:org:ws":{"phase":"Ready","count":3},"system:admin":{"phase":"Ready","count":0}}`
+	if string(raw) != expected {
+		t.Errorf("incorrect marshalled bytes, expected %s, got %s", expected, raw)
+	}
+
+	var got NameMap[state]
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, m) {
+		t.Errorf("round trip mismatch, expected %v, got %v", m, got)
+	}
+
+	var empty NameMap[int]
+	if raw, err := json.Marshal(empty); err != nil || string(raw) != "null" {
+		t.Errorf("json.Marshal(nil) = (%s, %v), want (null, nil)", raw, err)
+	}
+	if err := json.Unmarshal([]byte(`{}`), &empty); err != nil || empty == nil || len(empty) != 0 {
+		t.Errorf("json.Unmarshal({}) = (%v, %v), want empty map", empty, err)
+	}
+}
+
+func TestNameMap_UnmarshalJSONInvalidKeys(t *testing.T) {
+	tests := []struct {
+		data    string
+		wantErr string
+	}{
+		{`{"":1}`, `invalid logical cluster name "": must not be empty`},
+		{`{"root:":1}`, `invalid logical cluster name "root:": empty segment at index 5`},
+		{`{"root:Foo":1}`, `invalid logical cluster name "root:Foo": illegal character 'F' at index 5`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.data, func(t *testing.T) {
+			m := NameMap[int]{New("root:a"): 1}
+			err := json.Unmarshal([]byte(tt.data), &m)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("incorrect error, expected %s, got %v", tt.wantErr, err)
+			}
+			if len(m) != 1 {
+				t.Errorf("expected map to be unchanged, got %v", m)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalcluster
+
+import "sort"
+
+// OrderedMap is a map keyed by logical cluster names which iterates in
+// hierarchical order as defined by Name.Compare. The zero value is an empty map
+// ready to use.
+type OrderedMap[V any] struct {
+	keys   []Name
+	values map[Name]V
+}
+
+// Set sets the value for key.
+func (m *OrderedMap[V]) Set(key Name, value V) {
+	if m.values == nil {
+		m.values = map[Name]V{}
+	}
+	if _, found := m.values[key]; !found {
+		i := m.search(key)
+		m.keys = append(m.keys, Name{})
+		copy(m.keys[i+1:], m.keys[i:])
+		m.keys[i] = key
+	}
+	m.values[key] = value
+}
+
+// Get returns the value for key and whether it was found.
+func (m *OrderedMap[V]) Get(key Name) (V, bool) {
+	value, found := m.values[key]
+	return value, found
+}
+
+// Delete removes key from the map. Absent keys are ignored.
+func (m *OrderedMap[V]) Delete(key Name) {
+	if _, found := m.values[key]; !found {
+		return
+	}
+	delete(m.values, key)
+	i := m.search(key)
+	m.keys = append(m.keys[:i], m.keys[i+1:]...)
+}
+
+// Len returns the number of entries in the map.
+func (m *OrderedMap[V]) Len() int {
+	return len(m.keys)
+}
+
+// Range calls fn for each entry in hierarchical order of the keys, stopping
+// when fn returns false. The map must not be modified by fn.
+func (m *OrderedMap[V]) Range(fn func(Name, V) bool) {
+	for _, key := range m.keys {
+		if !fn(key, m.values[key]) {
+			return
+		}
+	}
+}
+
+// search returns the index of the first key not sorting before key.
+func (m *OrderedMap[V]) search(key Name) int {
+	return sort.Search(len(m.keys), func(i int) bool {
+		return !m.keys[i].Less(key)
+	})
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalcluster
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestOrderedMap(t *testing.T) {
+	var m OrderedMap[int]
+	m.Range(func(Name, int) bool {
+		t.Errorf("unexpected entry in empty map")
+		return true
+	})
+
+	names := []Name{
+		New("root:b"),
+		New("root:a:b"),
+		Wildcard,
+		New("root"),
+		New(""),
+		New("root:ab"),
+		New("root:a"),
+		New("root-a"),
+	}
+	for i, n := range names {
+		m.Set(n, i)
+	}
+	m.Set(New("root"), 42)
+	if m.Len() != len(names) {
+		t.Errorf("Len() = %d, want %d", m.Len(), len(names))
+	}
+	if v, ok := m.Get(New("root")); !ok || v != 42 {
+		t.Errorf("Get(%q) = (%d, %v), want (42, true)", "root", v, ok)
+	}
+	if v, ok := m.Get(New("other")); ok || v != 0 {
+		t.Errorf("Get(%q) = (%d, %v), want (0, false)", "other", v, ok)
+	}
+
+	m.Delete(New("root:ab"))
+	m.Delete(New("absent"))
+
+	expected := NameSlice{}
+	for _, n := range names {
+		if n != New("root:ab") {
+			expected = append(expected, n)
+		}
+	}
+	sort.Sort(expected)
+
+	var got NameSlice
+	m.Range(func(n Name, _ int) bool {
+		got = append(got, n)
+		return true
+	})
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Range() visited %v, want %v", got, expected)
+	}
+
+	got = nil
+	m.Range(func(n Name, _ int) bool {
+		got = append(got, n)
+		return len(got) < 2
+	})
+	if !reflect.DeepEqual(got, expected[:2]) {
+		t.Errorf("Range() with early stop visited %v, want %v", got, expected[:2])
+	}
+}
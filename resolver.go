@@ -0,0 +1,45 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalcluster
+
+import "fmt"
+
+// Resolver resolves a hashed root of a logical cluster name, as defined by
+// IsHashSegment, to the human-readable name it stands for, e.g. "c8a942c5" to
+// "root:accounting". The lookup itself is up to the implementation.
+type Resolver interface {
+	Resolve(root Name) (Name, error)
+}
+
+// ResolveWith replaces a hashed root of the logical cluster name by the name r
+// resolves it to, e.g. "root:accounting:us-west:invoices" for
+// "c8a942c5:us-west:invoices". Names without a hashed root are returned
+// unchanged without calling r.
+func (n Name) ResolveWith(r Resolver) (Name, error) {
+	if !n.RootIsHash() {
+		return n, nil
+	}
+	root, _ := n.Root()
+	resolved, err := r.Resolve(root)
+	if err != nil {
+		return Name{}, fmt.Errorf("failed to resolve root %q of logical cluster name %q: %w", root, n, err)
+	}
+	if tail, ok := n.Tail(); ok {
+		return resolved.Join(tail.value), nil
+	}
+	return resolved, nil
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalcluster
+
+import (
+	"errors"
+	"testing"
+)
+
+var errNotFound = errors.New("not found")
+
+type fakeResolver map[Name]Name
+
+func (r fakeResolver) Resolve(root Name) (Name, error) {
+	if n, ok := r[root]; ok {
+		return n, nil
+	}
+	return Name{}, errNotFound
+}
+
+func TestName_ResolveWith(t *testing.T) {
+	resolver := fakeResolver{
+		New("c8a942c5"): New("root:accounting"),
+		New("62208dab"): New("root"),
+	}
+	tests := []struct {
+		name    Name
+		want    Name
+		wantErr string
+	}{
+		{New(""), New(""), ""},
+		{Wildcard, Wildcard, ""},
+		{New("root:a"), New("root:a"), ""},
+		{New("deadbeef0:a"), New("deadbeef0:a"), ""},
+		{New("c8a942c5"), New("root:accounting"), ""},
+		{New("c8a942c5:us-west:invoices"), New("root:accounting:us-west:invoices"), ""},
+		{New("62208dab:a"), New("root:a"), ""},
+		{New("62208dab:"), New("root:"), ""},
+		{New("0000beef:a"), New(""), `failed to resolve root "0000beef" of logical cluster name "0000beef:a": not found`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name.String(), func(t *testing.T) {
+			got, err := tt.name.ResolveWith(resolver)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("incorrect error, expected %s, got %v", tt.wantErr, err)
+				}
+				if !errors.Is(err, errNotFound) {
+					t.Errorf("expected error to wrap %v, got %v", errNotFound, err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("%q.ResolveWith() = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalcluster
+
+import "sort"
+
+// NameSet is a set of logical cluster names, implemented via map[Name]struct{}
+// for minimal memory consumption.
+type NameSet map[Name]struct{}
+
+// NewNameSet creates a NameSet from a list of names.
+func NewNameSet(items ...Name) NameSet {
+	s := make(NameSet, len(items))
+	s.Insert(items...)
+	return s
+}
+
+// Insert adds items to the set.
+func (s NameSet) Insert(items ...Name) NameSet {
+	for _, item := range items {
+		s[item] = struct{}{}
+	}
+	return s
+}
+
+// Delete removes all items from the set. Absent items are ignored.
+func (s NameSet) Delete(items ...Name) NameSet {
+	for _, item := range items {
+		delete(s, item)
+	}
+	return s
+}
+
+// Has returns true if and only if item is contained in the set.
+func (s NameSet) Has(item Name) bool {
+	_, contained := s[item]
+	return contained
+}
+
+// Len returns the size of the set.
+func (s NameSet) Len() int {
+	return len(s)
+}
+
+// UnsortedList returns the names of the set in random order.
+func (s NameSet) UnsortedList() []Name {
+	res := make([]Name, 0, len(s))
+	for item := range s {
+		res = append(res, item)
+	}
+	return res
+}
+
+// SortedList returns the names of the set in hierarchical order as defined by
+// Name.Compare.
+func (s NameSet) SortedList() []Name {
+	res := s.UnsortedList()
+	sort.Sort(NameSlice(res))
+	return res
+}
+
+// Union returns a new set with the names contained in s or s2.
+func (s NameSet) Union(s2 NameSet) NameSet {
+	result := make(NameSet, len(s)+len(s2))
+	for item := range s {
+		result.Insert(item)
+	}
+	for item := range s2 {
+		result.Insert(item)
+	}
+	return result
+}
+
+// Intersection returns a new set with the names contained in both s and s2.
+func (s NameSet) Intersection(s2 NameSet) NameSet {
+	walk, other := s, s2
+	if len(s2) < len(s) {
+		walk, other = s2, s
+	}
+	result := NameSet{}
+	for item := range walk {
+		if other.Has(item) {
+			result.Insert(item)
+		}
+	}
+	return result
+}
+
+// Difference returns a new set with the names contained in s, but not in s2.
+func (s NameSet) Difference(s2 NameSet) NameSet {
+	result := NameSet{}
+	for item := range s {
+		if !s2.Has(item) {
+			result.Insert(item)
+		}
+	}
+	return result
+}
+
+// Dedup returns the names without duplicates, keeping the first occurrence of
+// each name in the original order.
+func Dedup(names []Name) []Name {
+	seen := make(NameSet, len(names))
+	res := make([]Name, 0, len(names))
+	for _, n := range names {
+		if !seen.Has(n) {
+			seen.Insert(n)
+			res = append(res, n)
+		}
+	}
+	return res
+}
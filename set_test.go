@@ -0,0 +1,161 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalcluster
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestNameSet(t *testing.T) {
+	s := NewNameSet()
+	if s.Len() != 0 {
+		t.Errorf("expected empty set, got %v", s)
+	}
+
+	s.Insert(New("root:a"), New("root:b"))
+	s.Insert(New("root:a"), Wildcard)
+	if s.Len() != 3 {
+		t.Errorf("expected 3 items after duplicate insertion, got %v", s)
+	}
+	for _, n := range []Name{New("root:a"), New("root:b"), Wildcard} {
+		if !s.Has(n) {
+			t.Errorf("expected set to contain %q", n)
+		}
+	}
+	if s.Has(New("root")) {
+		t.Errorf("expected set not to contain %q", "root")
+	}
+
+	s.Delete(New("root:b"), New("root:absent"))
+	if s.Len() != 2 || s.Has(New("root:b")) {
+		t.Errorf("expected %q to be deleted, got %v", "root:b", s)
+	}
+
+	list := s.UnsortedList()
+	sort.Sort(NameSlice(list))
+	if expected := []Name{Wildcard, New("root:a")}; !reflect.DeepEqual(list, expected) {
+		t.Errorf("UnsortedList() = %v, want %v", list, expected)
+	}
+
+	if !reflect.DeepEqual(NewNameSet(New("a"), New("a"), New("b")), NewNameSet(New("b"), New("a"))) {
+		t.Errorf("expected NewNameSet to deduplicate")
+	}
+	if list := NewNameSet().UnsortedList(); list == nil || len(list) != 0 {
+		t.Errorf("expected empty non-nil list, got %#v", list)
+	}
+}
+
+func TestNameSet_SortedList(t *testing.T) {
+	s := NewNameSet(New("root:b"), New("root:a:b"), New("root"), New("root:ab"), New("root:a"))
+	expected := []Name{New("root"), New("root:a"), New("root:a:b"), New("root:ab"), New("root:b")}
+	if got := s.SortedList(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("SortedList() = %v, want %v", got, expected)
+	}
+}
+
+func TestNameSet_Algebra(t *testing.T) {
+	tests := []struct {
+		name                                  string
+		a, b                                  NameSet
+		union, intersection, aMinusB, bMinusA NameSet
+	}{
+		{
+			name:         "disjoint",
+			a:            NewNameSet(New("root:a"), New("root:b")),
+			b:            NewNameSet(New("root:c")),
+			union:        NewNameSet(New("root:a"), New("root:b"), New("root:c")),
+			intersection: NewNameSet(),
+			aMinusB:      NewNameSet(New("root:a"), New("root:b")),
+			bMinusA:      NewNameSet(New("root:c")),
+		},
+		{
+			name:         "overlapping",
+			a:            NewNameSet(New("root:a"), New("root:b")),
+			b:            NewNameSet(New("root:b"), New("root:c")),
+			union:        NewNameSet(New("root:a"), New("root:b"), New("root:c")),
+			intersection: NewNameSet(New("root:b")),
+			aMinusB:      NewNameSet(New("root:a")),
+			bMinusA:      NewNameSet(New("root:c")),
+		},
+		{
+			name:         "identical",
+			a:            NewNameSet(New("root:a"), New("root:b")),
+			b:            NewNameSet(New("root:a"), New("root:b")),
+			union:        NewNameSet(New("root:a"), New("root:b")),
+			intersection: NewNameSet(New("root:a"), New("root:b")),
+			aMinusB:      NewNameSet(),
+			bMinusA:      NewNameSet(),
+		},
+		{
+			name:         "empty",
+			a:            NewNameSet(),
+			b:            NewNameSet(New("root:a")),
+			union:        NewNameSet(New("root:a")),
+			intersection: NewNameSet(),
+			aMinusB:      NewNameSet(),
+			bMinusA:      NewNameSet(New("root:a")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := tt.a.SortedList(), tt.b.SortedList()
+
+			if got := tt.a.Union(tt.b); !reflect.DeepEqual(got, tt.union) {
+				t.Errorf("Union() = %v, want %v", got, tt.union)
+			}
+			if got := tt.a.Intersection(tt.b); !reflect.DeepEqual(got, tt.intersection) {
+				t.Errorf("Intersection() = %v, want %v", got, tt.intersection)
+			}
+			if got := tt.b.Intersection(tt.a); !reflect.DeepEqual(got, tt.intersection) {
+				t.Errorf("Intersection() = %v, want %v", got, tt.intersection)
+			}
+			if got := tt.a.Difference(tt.b); !reflect.DeepEqual(got, tt.aMinusB) {
+				t.Errorf("Difference() = %v, want %v", got, tt.aMinusB)
+			}
+			if got := tt.b.Difference(tt.a); !reflect.DeepEqual(got, tt.bMinusA) {
+				t.Errorf("Difference() = %v, want %v", got, tt.bMinusA)
+			}
+
+			if !reflect.DeepEqual(tt.a.SortedList(), a) || !reflect.DeepEqual(tt.b.SortedList(), b) {
+				t.Errorf("operands were mutated")
+			}
+		})
+	}
+}
+
+func TestDedup(t *testing.T) {
+	tests := []struct {
+		name  string
+		names []Name
+		want  []Name
+	}{
+		{"nil", nil, []Name{}},
+		{"empty", []Name{}, []Name{}},
+		{"no duplicates", []Name{New("root:b"), New("root:a")}, []Name{New("root:b"), New("root:a")}},
+		{"duplicates", []Name{New("root:b"), New("root:a"), New("root:b"), Wildcard, New("root:a"), New("*")}, []Name{New("root:b"), New("root:a"), Wildcard}},
+		{"empty names", []Name{New(""), New("root"), New("")}, []Name{New(""), New("root")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Dedup(tt.names); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Dedup(%v) = %v, want %v", tt.names, got, tt.want)
+			}
+		})
+	}
+}
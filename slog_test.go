@@ -0,0 +1,49 @@
+//go:build go1.21
+
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalcluster
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+var _ slog.LogValuer = Name{}
+
+func TestName_LogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Info("reconciling", "cluster", New("root:a"), "parent", New("root"))
+
+	if got, want := strings.TrimSpace(buf.String()), `level=INFO msg=reconciling cluster=root:a parent=root`; got != want {
+		t.Errorf("incorrect log line, expected %s, got %s", want, got)
+	}
+
+	if got := New("root:a").LogValue(); got.Kind() != slog.KindString || got.String() != "root:a" {
+		t.Errorf("LogValue() = %v (%s), want string %q", got, got.Kind(), "root:a")
+	}
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalcluster
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Value implements driver.Valuer, storing the name as its string representation.
+func (n Name) Value() (driver.Value, error) {
+	return n.value, nil
+}
+
+// Scan implements sql.Scanner. It accepts string and []byte sources holding the
+// empty name or a valid logical cluster name. A nil source, i.e. SQL NULL, scans
+// to the empty name.
+func (n *Name) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		n.value = ""
+		return nil
+	case string:
+		return n.UnmarshalText([]byte(src))
+	case []byte:
+		return n.UnmarshalText(src)
+	default:
+		return fmt.Errorf("cannot scan %T into logical cluster name", src)
+	}
+}
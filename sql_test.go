@@ -0,0 +1,71 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalcluster
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+)
+
+var (
+	_ driver.Valuer = Name{}
+	_ sql.Scanner   = &Name{}
+)
+
+func TestName_Value(t *testing.T) {
+	for _, name := range []Name{New(""), Wildcard, New("root:a")} {
+		v, err := name.Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v != name.String() {
+			t.Errorf("%q.Value() = %v, want %q", name, v, name.String())
+		}
+	}
+}
+
+func TestName_Scan(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     interface{}
+		want    Name
+		wantErr bool
+	}{
+		{"string", "root:a", New("root:a"), false},
+		{"bytes", []byte("root:a"), New("root:a"), false},
+		{"empty string", "", New(""), false},
+		{"empty bytes", []byte{}, New(""), false},
+		{"wildcard", "*", Wildcard, false},
+		{"invalid string", "root:A", New(""), true},
+		{"invalid bytes", []byte("root::a"), New(""), true},
+		{"nil", nil, New(""), false},
+		{"int", int64(42), New(""), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := New("previous")
+			err := got.Scan(tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("Scan(%v) = %q, want %q", tt.src, got, tt.want)
+			}
+		})
+	}
+}